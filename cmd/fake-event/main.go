@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event/fake"
+	"google.golang.org/grpc"
+	"log/slog"
+	"net"
+	"os"
+)
+
+// Runs the in-memory event service, start the gateway with
+// EVENT_SERVICE_ADDRESS pointing at it to work on events locally.
+func main() {
+	addr := flag.String("addr", "localhost:44046", "address to listen on")
+	flag.Parse()
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Error("failed to listen", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	gs := grpc.NewServer()
+	fake.NewServer().Register(gs)
+
+	log.Info("fake event service started", slog.String("address", *addr))
+	if err := gs.Serve(lis); err != nil {
+		log.Error("serve error", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
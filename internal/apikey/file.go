@@ -0,0 +1,77 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jsonfile"
+	"sync"
+	"time"
+)
+
+// FileStorage keeps keys in memory and writes the whole set to a JSON file on every change.
+// It is meant for a single gateway instance with a small number of keys.
+type FileStorage struct {
+	mu   sync.Mutex
+	path string
+	mem  *MemoryStorage
+}
+
+// NewFileStorage loads the keys from path, a missing file is treated as empty.
+func NewFileStorage(path string) (*FileStorage, error) {
+	const op = "apikey.NewFileStorage"
+
+	s := &FileStorage{path: path, mem: NewMemoryStorage()}
+
+	var keys []Key
+	if err := jsonfile.Load(path, &keys); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, key := range keys {
+		s.mem.keys[key.ID] = key
+	}
+
+	return s, nil
+}
+
+func (s *FileStorage) Save(ctx context.Context, key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Save(ctx, key); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+func (s *FileStorage) Get(ctx context.Context, id string) (Key, error) {
+	return s.mem.Get(ctx, id)
+}
+
+func (s *FileStorage) List(ctx context.Context) ([]Key, error) {
+	return s.mem.List(ctx)
+}
+
+func (s *FileStorage) Revoke(ctx context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Revoke(ctx, id, at); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+// flush atomically replaces the file with the current set of keys.
+func (s *FileStorage) flush(ctx context.Context) error {
+	const op = "apikey.FileStorage.flush"
+
+	keys, err := s.mem.List(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := jsonfile.Save(s.path, keys); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ContextKey is the gin context key holding the Key of an API key authenticated request.
+const ContextKey = "apiKey"
+
+const keyPrefix = "uck"
+
+const (
+	ScopeClubsRead    = "clubs:read"
+	ScopeClubsApprove = "clubs:approve"
+	ScopeUsersRead    = "users:read"
+)
+
+// Scopes lists every scope that can be granted to a key.
+var Scopes = []string{
+	ScopeClubsRead,
+	ScopeClubsApprove,
+	ScopeUsersRead,
+}
+
+var (
+	ErrNotFound     = errors.New("api key not found")
+	ErrInvalidKey   = errors.New("invalid api key")
+	ErrExpired      = errors.New("api key is expired")
+	ErrRevoked      = errors.New("api key is revoked")
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+type Key struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Hash    string   `json:"hash"`
+	OwnerID int64    `json:"owner_id"`
+	Scopes  []string `json:"scopes"`
+	// RateLimit is the number of requests allowed per minute, 0 means unlimited.
+	RateLimit int        `json:"rate_limit"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k Key) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate reports whether the key can be used at the given time.
+func (k Key) Validate(now time.Time) error {
+	if k.RevokedAt != nil {
+		return ErrRevoked
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return ErrExpired
+	}
+	return nil
+}
+
+// generate creates a new plaintext key in the form "uck_<id>_<secret>".
+// The id is stored in clear to look the key up, only the hash of the whole key is persisted.
+func generate() (id, plaintext string, err error) {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	id = hex.EncodeToString(idBytes)
+	return id, fmt.Sprintf("%s_%s_%s", keyPrefix, id, base64.RawURLEncoding.EncodeToString(secret)), nil
+}
+
+// parseID extracts the key id from a plaintext key.
+func parseID(plaintext string) (string, error) {
+	parts := strings.SplitN(plaintext, "_", 3)
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", ErrInvalidKey
+	}
+	return parts[1], nil
+}
+
+func hash(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
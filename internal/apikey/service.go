@@ -0,0 +1,115 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Service struct {
+	storage Storage
+}
+
+func NewService(storage Storage) *Service {
+	return &Service{storage: storage}
+}
+
+type CreateParams struct {
+	Name      string
+	OwnerID   int64
+	Scopes    []string
+	RateLimit int
+	ExpiresAt *time.Time
+}
+
+// Create generates a new key and stores its hash.
+// The plaintext key is returned only once, it cannot be recovered later.
+func (s *Service) Create(ctx context.Context, params CreateParams) (string, Key, error) {
+	const op = "apikey.Service.Create"
+
+	for _, scope := range params.Scopes {
+		if !validScope(scope) {
+			return "", Key{}, fmt.Errorf("%s: %w: %s", op, ErrInvalidScope, scope)
+		}
+	}
+
+	id, plaintext, err := generate()
+	if err != nil {
+		return "", Key{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := Key{
+		ID:        id,
+		Name:      params.Name,
+		Hash:      hash(plaintext),
+		OwnerID:   params.OwnerID,
+		Scopes:    params.Scopes,
+		RateLimit: params.RateLimit,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.storage.Save(ctx, key); err != nil {
+		return "", Key{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return plaintext, key, nil
+}
+
+// Authenticate looks up the key and checks its hash, expiry and revocation.
+func (s *Service) Authenticate(ctx context.Context, plaintext string) (Key, error) {
+	const op = "apikey.Service.Authenticate"
+
+	id, err := parseID(plaintext)
+	if err != nil {
+		return Key{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key, err := s.storage.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Key{}, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+		}
+		return Key{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hash(plaintext))) != 1 {
+		return Key{}, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+	}
+
+	if err := key.Validate(time.Now()); err != nil {
+		return Key{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]Key, error) {
+	const op = "apikey.Service.List"
+
+	keys, err := s.storage.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return keys, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, id string) error {
+	const op = "apikey.Service.Revoke"
+
+	if err := s.storage.Revoke(ctx, id, time.Now().UTC()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func validScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
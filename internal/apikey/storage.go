@@ -0,0 +1,16 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+// Storage persists API keys. Implementations store only key hashes.
+type Storage interface {
+	Save(ctx context.Context, key Key) error
+	// Get returns ErrNotFound if there is no key with the id.
+	Get(ctx context.Context, id string) (Key, error)
+	List(ctx context.Context) ([]Key, error)
+	// Revoke returns ErrNotFound if there is no key with the id.
+	Revoke(ctx context.Context, id string, at time.Time) error
+}
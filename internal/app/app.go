@@ -2,11 +2,19 @@ package app
 
 import (
 	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/apikey"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/app/httpsvr"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/attendance"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/auth"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/notification"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/handler"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/realtime"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/webhook"
 	"log/slog"
 )
 
@@ -54,9 +62,134 @@ func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *App {
 		panic(err)
 	}
 
-	h := handler.New(log, userClient, clubClient)
+	eventClient, err := event.New(ctx, log, cfg.Clients.Event.Address, cfg.Clients.Event.Timeout, cfg.Clients.Event.RetriesCount)
+	if err != nil {
+		log.Error("event service client init error", slog.Attr{
+			Key:   "error",
+			Value: slog.StringValue(err.Error()),
+		})
+		panic(err)
+	}
+
+	notificationClient, err := notification.New(ctx, log, cfg.Clients.Notification.Address, cfg.Clients.Notification.Timeout, cfg.Clients.Notification.RetriesCount)
+	if err != nil {
+		log.Error("notification service client init error", slog.Attr{
+			Key:   "error",
+			Value: slog.StringValue(err.Error()),
+		})
+		panic(err)
+	}
+
+	var tokens *auth.TokenIssuer
+	if cfg.JWT.Enabled {
+		tokens, err = auth.NewTokenIssuer(cfg.JWT, auth.NewMemoryRefreshStore())
+		if err != nil {
+			log.Error("token issuer init error", slog.Attr{
+				Key:   "error",
+				Value: slog.StringValue(err.Error()),
+			})
+			panic(err)
+		}
+	}
+
+	var apiKeyStorage apikey.Storage
+	switch cfg.APIKeys.Storage {
+	case "file":
+		apiKeyStorage, err = apikey.NewFileStorage(cfg.APIKeys.FilePath)
+		if err != nil {
+			log.Error("api key storage init error", slog.Attr{
+				Key:   "error",
+				Value: slog.StringValue(err.Error()),
+			})
+			panic(err)
+		}
+	default:
+		apiKeyStorage = apikey.NewMemoryStorage()
+	}
+
+	hub := realtime.NewHub(cfg.Realtime.BufferSize, cfg.Realtime.ClientBuffer, log)
+
+	var webhookStorage webhook.Storage
+	switch cfg.Webhooks.Storage {
+	case "file":
+		webhookStorage, err = webhook.NewFileStorage(cfg.Webhooks.FilePath)
+		if err != nil {
+			log.Error("webhook storage init error", slog.Attr{
+				Key:   "error",
+				Value: slog.StringValue(err.Error()),
+			})
+			panic(err)
+		}
+	default:
+		webhookStorage = webhook.NewMemoryStorage()
+	}
+
+	webhooks := webhook.NewService(webhookStorage, cfg.Webhooks, log)
+	webhookCtx, stopWebhooks := context.WithCancel(context.Background())
+	go webhooks.Run(webhookCtx)
+
+	var publisher broker.Publisher
+	switch cfg.Broker.Driver {
+	case "nats":
+		publisher, err = broker.ConnectNATS(cfg.Broker.NATSURL, cfg.Broker.SubjectPrefix)
+		if err != nil {
+			log.Error("nats connection error", slog.Attr{
+				Key:   "error",
+				Value: slog.StringValue(err.Error()),
+			})
+			panic(err)
+		}
+	default:
+		publisher = broker.NewMemoryPublisher()
+	}
+
+	var outboxStorage broker.OutboxStorage
+	switch cfg.Broker.OutboxStorage {
+	case "file":
+		outboxStorage, err = broker.NewFileOutboxStorage(cfg.Broker.OutboxFilePath)
+		if err != nil {
+			log.Error("outbox storage init error", slog.Attr{
+				Key:   "error",
+				Value: slog.StringValue(err.Error()),
+			})
+			panic(err)
+		}
+	default:
+		outboxStorage = broker.NewMemoryOutboxStorage()
+	}
+
+	outbox := broker.NewOutbox(outboxStorage, publisher, cfg.Broker, log)
+	outboxCtx, stopOutbox := context.WithCancel(context.Background())
+	go outbox.Run(outboxCtx)
+
+	var attendanceStorage attendance.Storage
+	switch cfg.Attendance.Storage {
+	case "file":
+		attendanceStorage, err = attendance.NewFileStorage(cfg.Attendance.FilePath)
+		if err != nil {
+			log.Error("attendance storage init error", slog.Attr{
+				Key:   "error",
+				Value: slog.StringValue(err.Error()),
+			})
+			panic(err)
+		}
+	default:
+		attendanceStorage = attendance.NewMemoryStorage()
+	}
+
+	h := handler.New(cfg, log, userClient, clubClient, eventClient, notificationClient, hub, tokens, apikey.NewService(apiKeyStorage), webhooks, outbox, attendance.NewService(attendanceStorage))
 
 	httpServer := httpsvr.New(cfg, h.InitRoutes())
+	// event streams never finish on their own, close them so shutdown does not wait for them
+	httpServer.HTTPServer.RegisterOnShutdown(hub.Close)
+	httpServer.HTTPServer.RegisterOnShutdown(stopWebhooks)
+	// with file storage, events still in the outbox are published after a restart
+	httpServer.HTTPServer.RegisterOnShutdown(func() {
+		stopOutbox()
+		if err := publisher.Close(); err != nil {
+			log.Error("failed to close event publisher", slog.String("error", err.Error()))
+		}
+	})
 
 	return &App{HTTPSvr: httpServer}
 }
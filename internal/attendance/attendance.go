@@ -0,0 +1,110 @@
+// Package attendance tracks who attended a club meeting. A session snapshots the
+// club roster when it is opened, and student cards are scanned against it by barcode.
+package attendance
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Check-in results.
+const (
+	StatusCheckedIn = "checked_in"
+	// StatusDuplicate marks a barcode that was already scanned in the session.
+	StatusDuplicate = "duplicate"
+	// StatusNotMember marks a barcode that is not on the session roster.
+	StatusNotMember = "not_member"
+)
+
+var (
+	ErrNotFound       = errors.New("attendance session not found")
+	ErrClosed         = errors.New("attendance session is closed")
+	ErrInvalidBarcode = errors.New("invalid barcode")
+)
+
+type Session struct {
+	ID       string     `json:"id"`
+	ClubID   int64      `json:"club_id"`
+	Title    string     `json:"title"`
+	OpenedBy int64      `json:"opened_by"`
+	OpenedAt time.Time  `json:"opened_at"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	// Roster is the club members at the time the session was opened.
+	Roster []Attendee `json:"-"`
+}
+
+func (s Session) Closed() bool {
+	return s.ClosedAt != nil
+}
+
+// Attendee returns the roster entry with the barcode.
+func (s Session) Attendee(barcode string) (Attendee, bool) {
+	for _, a := range s.Roster {
+		if a.Barcode == barcode {
+			return a, true
+		}
+	}
+	return Attendee{}, false
+}
+
+type Attendee struct {
+	UserID    int64  `json:"user_id"`
+	Barcode   string `json:"barcode"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// Scan is a single barcode scan, every scan is kept including duplicates.
+type Scan struct {
+	Barcode   string    `json:"barcode"`
+	UserID    int64     `json:"user_id,omitempty"`
+	Member    bool      `json:"member"`
+	Duplicate bool      `json:"duplicate"`
+	ScannedBy int64     `json:"scanned_by"`
+	At        time.Time `json:"at"`
+}
+
+// Status returns the check-in result of the scan.
+func (s Scan) Status() string {
+	switch {
+	case s.Duplicate:
+		return StatusDuplicate
+	case !s.Member:
+		return StatusNotMember
+	default:
+		return StatusCheckedIn
+	}
+}
+
+type Counts struct {
+	Roster     int `json:"roster"`
+	CheckedIn  int `json:"checked_in"`
+	Absent     int `json:"absent"`
+	NonMembers int `json:"non_members"`
+	Duplicates int `json:"duplicates"`
+}
+
+// CountScans summarizes the scans of the session.
+func CountScans(session Session, scans []Scan) Counts {
+	counts := Counts{Roster: len(session.Roster)}
+	for _, scan := range scans {
+		switch scan.Status() {
+		case StatusCheckedIn:
+			counts.CheckedIn++
+		case StatusNotMember:
+			counts.NonMembers++
+		case StatusDuplicate:
+			counts.Duplicates++
+		}
+	}
+	counts.Absent = counts.Roster - counts.CheckedIn
+
+	return counts
+}
+
+// NormalizeBarcode trims the input of a scanner, scanners commonly send a trailing newline.
+func NormalizeBarcode(barcode string) string {
+	return strings.ToUpper(strings.TrimSpace(barcode))
+}
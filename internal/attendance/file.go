@@ -0,0 +1,107 @@
+package attendance
+
+import (
+	"context"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jsonfile"
+	"sync"
+	"time"
+)
+
+// FileStorage keeps sessions and scans in memory and writes them to a JSON file on
+// every change, so attendance survives a restart of the gateway. It is meant for a
+// single gateway instance.
+type FileStorage struct {
+	mu   sync.Mutex
+	path string
+	mem  *MemoryStorage
+}
+
+// fileSession is a session as stored in the file, the roster is not part of the
+// API representation of a session but has to be kept.
+type fileSession struct {
+	Session
+	Roster []Attendee `json:"roster"`
+	Scans  []Scan     `json:"scans"`
+}
+
+// NewFileStorage loads sessions and scans from path, a missing file is treated as empty.
+func NewFileStorage(path string) (*FileStorage, error) {
+	const op = "attendance.NewFileStorage"
+
+	s := &FileStorage{path: path, mem: NewMemoryStorage()}
+
+	var sessions []fileSession
+	if err := jsonfile.Load(path, &sessions); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, fs := range sessions {
+		session := fs.Session
+		session.Roster = fs.Roster
+		s.mem.sessions[session.ID] = session
+		s.mem.scans[session.ID] = fs.Scans
+	}
+
+	return s, nil
+}
+
+func (s *FileStorage) SaveSession(ctx context.Context, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.SaveSession(ctx, session); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *FileStorage) GetSession(ctx context.Context, id string) (Session, error) {
+	return s.mem.GetSession(ctx, id)
+}
+
+func (s *FileStorage) ListSessions(ctx context.Context, clubID int64) ([]Session, error) {
+	return s.mem.ListSessions(ctx, clubID)
+}
+
+func (s *FileStorage) CloseSession(ctx context.Context, id string, at time.Time) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.mem.CloseSession(ctx, id, at)
+	if err != nil {
+		return Session{}, err
+	}
+	return session, s.flush()
+}
+
+func (s *FileStorage) AddScan(ctx context.Context, sessionID string, scan Scan) (Scan, *Scan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, first, err := s.mem.AddScan(ctx, sessionID, scan)
+	if err != nil {
+		return Scan{}, nil, err
+	}
+	return stored, first, s.flush()
+}
+
+func (s *FileStorage) ListScans(ctx context.Context, sessionID string) ([]Scan, error) {
+	return s.mem.ListScans(ctx, sessionID)
+}
+
+// flush atomically replaces the file with the current sessions and scans.
+func (s *FileStorage) flush() error {
+	const op = "attendance.FileStorage.flush"
+
+	s.mem.mu.RLock()
+	sessions := make([]fileSession, 0, len(s.mem.sessions))
+	for id, session := range s.mem.sessions {
+		sessions = append(sessions, fileSession{Session: session, Roster: session.Roster, Scans: s.mem.scans[id]})
+	}
+	s.mem.mu.RUnlock()
+
+	if err := jsonfile.Save(s.path, sessions); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
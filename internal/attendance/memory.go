@@ -0,0 +1,103 @@
+package attendance
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type MemoryStorage struct {
+	mu       sync.RWMutex
+	sessions map[string]Session
+	scans    map[string][]Scan
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		sessions: make(map[string]Session),
+		scans:    make(map[string][]Scan),
+	}
+}
+
+func (s *MemoryStorage) SaveSession(_ context.Context, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *MemoryStorage) GetSession(_ context.Context, id string) (Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return Session{}, ErrNotFound
+	}
+	return session, nil
+}
+
+func (s *MemoryStorage) ListSessions(_ context.Context, clubID int64) ([]Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var sessions []Session
+	for _, session := range s.sessions {
+		if session.ClubID == clubID {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].OpenedAt.After(sessions[j].OpenedAt) })
+
+	return sessions, nil
+}
+
+func (s *MemoryStorage) CloseSession(_ context.Context, id string, at time.Time) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return Session{}, ErrNotFound
+	}
+	session.ClosedAt = &at
+	s.sessions[id] = session
+
+	return session, nil
+}
+
+func (s *MemoryStorage) AddScan(_ context.Context, sessionID string, scan Scan) (Scan, *Scan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[sessionID]; !ok {
+		return Scan{}, nil, ErrNotFound
+	}
+
+	var first *Scan
+	for _, prev := range s.scans[sessionID] {
+		if prev.Barcode == scan.Barcode {
+			first = &prev
+			break
+		}
+	}
+	scan.Duplicate = first != nil
+	s.scans[sessionID] = append(s.scans[sessionID], scan)
+
+	return scan, first, nil
+}
+
+func (s *MemoryStorage) ListScans(_ context.Context, sessionID string) ([]Scan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.sessions[sessionID]; !ok {
+		return nil, ErrNotFound
+	}
+	scans := make([]Scan, len(s.scans[sessionID]))
+	copy(scans, s.scans[sessionID])
+
+	return scans, nil
+}
@@ -0,0 +1,183 @@
+package attendance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/randid"
+	"time"
+)
+
+type Service struct {
+	storage Storage
+}
+
+func NewService(storage Storage) *Service {
+	return &Service{storage: storage}
+}
+
+type OpenParams struct {
+	ClubID   int64
+	Title    string
+	OpenedBy int64
+	Roster   []Attendee
+}
+
+// Open starts a session for the roster. Roster entries without a barcode cannot be
+// scanned and are left out.
+func (s *Service) Open(ctx context.Context, params OpenParams) (Session, error) {
+	const op = "attendance.Service.Open"
+
+	id, err := randid.New("att_", 8)
+	if err != nil {
+		return Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roster := make([]Attendee, 0, len(params.Roster))
+	for _, a := range params.Roster {
+		a.Barcode = NormalizeBarcode(a.Barcode)
+		if a.Barcode != "" {
+			roster = append(roster, a)
+		}
+	}
+
+	session := Session{
+		ID:       id,
+		ClubID:   params.ClubID,
+		Title:    params.Title,
+		OpenedBy: params.OpenedBy,
+		OpenedAt: time.Now().UTC(),
+		Roster:   roster,
+	}
+	if err := s.storage.SaveSession(ctx, session); err != nil {
+		return Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+// Session returns ErrNotFound if the session does not belong to the club.
+func (s *Service) Session(ctx context.Context, clubID int64, id string) (Session, error) {
+	const op = "attendance.Service.Session"
+
+	session, err := s.storage.GetSession(ctx, id)
+	if err != nil {
+		return Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if session.ClubID != clubID {
+		return Session{}, fmt.Errorf("%s: %w", op, ErrNotFound)
+	}
+
+	return session, nil
+}
+
+func (s *Service) Sessions(ctx context.Context, clubID int64) ([]Session, error) {
+	const op = "attendance.Service.Sessions"
+
+	sessions, err := s.storage.ListSessions(ctx, clubID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+// Close stops accepting check-ins, closing a closed session returns ErrClosed.
+func (s *Service) Close(ctx context.Context, clubID int64, id string) (Session, error) {
+	const op = "attendance.Service.Close"
+
+	session, err := s.Session(ctx, clubID, id)
+	if err != nil {
+		return Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if session.Closed() {
+		return Session{}, fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+
+	session, err = s.storage.CloseSession(ctx, id, time.Now().UTC())
+	if err != nil {
+		return Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+type CheckInResult struct {
+	Status   string    `json:"status"`
+	Barcode  string    `json:"barcode"`
+	Member   bool      `json:"member"`
+	Attendee *Attendee `json:"attendee,omitempty"`
+	At       time.Time `json:"at"`
+	// FirstScanAt is set for duplicates.
+	FirstScanAt *time.Time `json:"first_scan_at,omitempty"`
+	Counts      Counts     `json:"counts"`
+}
+
+// CheckIn records a scan of the barcode. Scans of barcodes that are not on the roster
+// and repeated scans are recorded as well, the result tells them apart.
+func (s *Service) CheckIn(ctx context.Context, clubID int64, id, barcode string, scannedBy int64) (CheckInResult, error) {
+	const op = "attendance.Service.CheckIn"
+
+	barcode = NormalizeBarcode(barcode)
+	if barcode == "" {
+		return CheckInResult{}, fmt.Errorf("%s: %w", op, ErrInvalidBarcode)
+	}
+
+	session, err := s.Session(ctx, clubID, id)
+	if err != nil {
+		return CheckInResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if session.Closed() {
+		return CheckInResult{}, fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+
+	scan := Scan{Barcode: barcode, ScannedBy: scannedBy, At: time.Now().UTC()}
+	attendee, member := session.Attendee(barcode)
+	if member {
+		scan.UserID = attendee.UserID
+		scan.Member = true
+	}
+
+	scan, first, err := s.storage.AddScan(ctx, id, scan)
+	if err != nil {
+		return CheckInResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	scans, err := s.storage.ListScans(ctx, id)
+	if err != nil {
+		return CheckInResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := CheckInResult{
+		Status:  scan.Status(),
+		Barcode: barcode,
+		Member:  member,
+		At:      scan.At,
+		Counts:  CountScans(session, scans),
+	}
+	if member {
+		result.Attendee = &attendee
+	}
+	if first != nil {
+		result.FirstScanAt = &first.At
+	}
+
+	return result, nil
+}
+
+// Scans returns the session together with its scans.
+func (s *Service) Scans(ctx context.Context, clubID int64, id string) (Session, []Scan, error) {
+	const op = "attendance.Service.Scans"
+
+	session, err := s.Session(ctx, clubID, id)
+	if err != nil {
+		return Session{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	scans, err := s.storage.ListScans(ctx, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return Session{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, scans, nil
+}
@@ -0,0 +1,24 @@
+package attendance
+
+import (
+	"context"
+	"time"
+)
+
+// Storage persists attendance sessions and their scans.
+type Storage interface {
+	SaveSession(ctx context.Context, session Session) error
+	// GetSession returns ErrNotFound if there is no session with the id.
+	GetSession(ctx context.Context, id string) (Session, error)
+	// ListSessions returns the sessions of the club, newest first.
+	ListSessions(ctx context.Context, clubID int64) ([]Session, error)
+	// CloseSession returns ErrNotFound if there is no session with the id.
+	CloseSession(ctx context.Context, id string, at time.Time) (Session, error)
+
+	// AddScan stores the scan. If the barcode was already scanned in the session the
+	// scan is stored with Duplicate set, and the first scan of the barcode is returned as well.
+	// Returns ErrNotFound if there is no session with the id.
+	AddScan(ctx context.Context, sessionID string, scan Scan) (stored Scan, first *Scan, err error)
+	// ListScans returns the scans of the session in the order they were made.
+	ListScans(ctx context.Context, sessionID string) ([]Scan, error)
+}
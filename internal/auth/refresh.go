@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RefreshToken is the gateway side record of an issued refresh token. The JWT handed
+// to the client only carries the ID and SessionID, the session token stays here.
+type RefreshToken struct {
+	ID string
+	// SessionID groups the refresh tokens rotated from the same sign-in.
+	SessionID    string
+	UserID       int64
+	SessionToken string
+	ExpiresAt    time.Time
+	UsedAt       *time.Time
+}
+
+// RefreshStore persists issued refresh tokens.
+type RefreshStore interface {
+	Save(ctx context.Context, token RefreshToken) error
+	// Get returns the token without marking it. Returns ErrInvalidToken if the token is
+	// unknown or expired and ErrRefreshReused if it was used before.
+	Get(ctx context.Context, id string, at time.Time) (RefreshToken, error)
+	// Use marks the token as used and returns it. Returns ErrInvalidToken if the token is
+	// unknown or expired and ErrRefreshReused if it was used before.
+	Use(ctx context.Context, id string, at time.Time) (RefreshToken, error)
+	// SessionToken returns the user service session behind the session id.
+	// Returns ErrInvalidToken if the session id has no live refresh tokens.
+	SessionToken(ctx context.Context, sessionID string) (string, error)
+	// Revoke removes every refresh token of the session id.
+	Revoke(ctx context.Context, sessionID string) error
+}
+
+type MemoryRefreshStore struct {
+	mu       sync.Mutex
+	tokens   map[string]RefreshToken
+	sessions map[string]map[string]struct{}
+}
+
+func NewMemoryRefreshStore() *MemoryRefreshStore {
+	return &MemoryRefreshStore{
+		tokens:   make(map[string]RefreshToken),
+		sessions: make(map[string]map[string]struct{}),
+	}
+}
+
+func (s *MemoryRefreshStore) Save(_ context.Context, token RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(time.Now())
+
+	s.tokens[token.ID] = token
+	if s.sessions[token.SessionID] == nil {
+		s.sessions[token.SessionID] = make(map[string]struct{})
+	}
+	s.sessions[token.SessionID][token.ID] = struct{}{}
+
+	return nil
+}
+
+func (s *MemoryRefreshStore) Get(_ context.Context, id string, at time.Time) (RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[id]
+	if !ok || !at.Before(token.ExpiresAt) {
+		return RefreshToken{}, ErrInvalidToken
+	}
+	if token.UsedAt != nil {
+		return token, ErrRefreshReused
+	}
+
+	return token, nil
+}
+
+func (s *MemoryRefreshStore) Use(_ context.Context, id string, at time.Time) (RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[id]
+	if !ok || !at.Before(token.ExpiresAt) {
+		return RefreshToken{}, ErrInvalidToken
+	}
+	if token.UsedAt != nil {
+		return token, ErrRefreshReused
+	}
+
+	token.UsedAt = &at
+	s.tokens[id] = token
+
+	return token, nil
+}
+
+func (s *MemoryRefreshStore) SessionToken(_ context.Context, sessionID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id := range s.sessions[sessionID] {
+		if token := s.tokens[id]; now.Before(token.ExpiresAt) {
+			return token.SessionToken, nil
+		}
+	}
+
+	return "", ErrInvalidToken
+}
+
+func (s *MemoryRefreshStore) Revoke(_ context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id := range s.sessions[sessionID] {
+		delete(s.tokens, id)
+	}
+	delete(s.sessions, sessionID)
+
+	return nil
+}
+
+// prune drops expired tokens, must be called with mu held.
+func (s *MemoryRefreshStore) prune(now time.Time) {
+	for id, token := range s.tokens {
+		if now.Before(token.ExpiresAt) {
+			continue
+		}
+		delete(s.tokens, id)
+		delete(s.sessions[token.SessionID], id)
+		if len(s.sessions[token.SessionID]) == 0 {
+			delete(s.sessions, token.SessionID)
+		}
+	}
+}
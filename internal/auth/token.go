@@ -0,0 +1,302 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jwt"
+	"strconv"
+	"time"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+var (
+	ErrUnknownKey       = errors.New("unknown signing key")
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrInvalidTokenType = errors.New("invalid token type")
+	// ErrRefreshReused is returned when a refresh token is presented a second time,
+	// every token of its session is revoked because one of them has leaked.
+	ErrRefreshReused = errors.New("refresh token was already used")
+)
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Type string `json:"typ"`
+	// SessionID is an opaque reference to the user service session the tokens were
+	// minted for, the session token itself never leaves the gateway.
+	SessionID string `json:"sid,omitempty"`
+}
+
+// TokenIssuer mints and verifies the gateway's signed access and refresh tokens.
+type TokenIssuer struct {
+	issuer      string
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	activeKeyID string
+	keys        map[string][]byte
+	store       RefreshStore
+}
+
+type TokenPair struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// NewTokenIssuer creates a TokenIssuer from the JWT config, issued refresh tokens are kept in store.
+// Returns an error if the active key is not present among the configured keys.
+func NewTokenIssuer(cfg config.JWTConfig, store RefreshStore) (*TokenIssuer, error) {
+	const op = "auth.NewTokenIssuer"
+
+	keys := make(map[string][]byte, len(cfg.Keys))
+	for kid, secret := range cfg.Keys {
+		keys[kid] = []byte(secret)
+	}
+
+	if _, ok := keys[cfg.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("%s: active key %q: %w", op, cfg.ActiveKeyID, ErrUnknownKey)
+	}
+
+	return &TokenIssuer{
+		issuer:      cfg.Issuer,
+		accessTTL:   cfg.AccessTTL,
+		refreshTTL:  cfg.RefreshTTL,
+		activeKeyID: cfg.ActiveKeyID,
+		keys:        keys,
+		store:       store,
+	}, nil
+}
+
+// IssuePair mints an access token for the user and a refresh token bound to the session.
+func (i *TokenIssuer) IssuePair(ctx context.Context, userID int64, sessionToken string) (TokenPair, error) {
+	const op = "auth.TokenIssuer.IssuePair"
+
+	sessionID, err := randomID()
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pair, err := i.issue(ctx, userID, sessionID, sessionToken)
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pair, nil
+}
+
+// Refresh verifies a refresh token and returns its record, a refresh token is accepted
+// only once. Presenting a used token revokes the whole session and returns ErrRefreshReused.
+// The token is only used up by Rotate, which is called once the session is known to be
+// active, so a failure in between leaves the token valid for a retry.
+func (i *TokenIssuer) Refresh(ctx context.Context, refreshToken string) (RefreshToken, error) {
+	const op = "auth.TokenIssuer.Refresh"
+
+	claims, err := i.parse(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err := i.store.Get(ctx, claims.ID, time.Now())
+	if errors.Is(err, ErrRefreshReused) {
+		if err := i.store.Revoke(ctx, claims.SessionID); err != nil {
+			return RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+		}
+		return RefreshToken{}, fmt.Errorf("%s: %w", op, ErrRefreshReused)
+	}
+	if err != nil {
+		return RefreshToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userID, err := i.userID(claims)
+	if err != nil || userID != token.UserID || claims.SessionID != token.SessionID {
+		return RefreshToken{}, fmt.Errorf("%s: %w: claims do not match the stored token", op, ErrInvalidToken)
+	}
+
+	return token, nil
+}
+
+// Rotate mints a new token pair in the session of a refresh token returned by Refresh
+// and uses up that token. The new pair is issued first, so a failure leaves the old
+// token valid. If the token was used concurrently the session is revoked and
+// ErrRefreshReused is returned.
+func (i *TokenIssuer) Rotate(ctx context.Context, used RefreshToken) (TokenPair, error) {
+	const op = "auth.TokenIssuer.Rotate"
+
+	pair, err := i.issue(ctx, used.UserID, used.SessionID, used.SessionToken)
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = i.store.Use(ctx, used.ID, time.Now())
+	if errors.Is(err, ErrRefreshReused) {
+		if err := i.store.Revoke(ctx, used.SessionID); err != nil {
+			return TokenPair{}, fmt.Errorf("%s: %w", op, err)
+		}
+		return TokenPair{}, fmt.Errorf("%s: %w", op, ErrRefreshReused)
+	}
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pair, nil
+}
+
+// Revoke invalidates every refresh token of the session id.
+func (i *TokenIssuer) Revoke(ctx context.Context, sessionID string) error {
+	const op = "auth.TokenIssuer.Revoke"
+
+	if err := i.store.Revoke(ctx, sessionID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// ParseAccessToken verifies an access token and returns the user id it was issued for.
+func (i *TokenIssuer) ParseAccessToken(token string) (int64, error) {
+	claims, err := i.parse(token, TokenTypeAccess)
+	if err != nil {
+		return 0, err
+	}
+
+	return i.userID(claims)
+}
+
+// EndSession resolves the user service session of an access token and revokes the
+// refresh tokens minted with it. The session token is empty if the refresh tokens
+// are already gone, e.g. expired or revoked.
+func (i *TokenIssuer) EndSession(ctx context.Context, accessToken string) (string, error) {
+	const op = "auth.TokenIssuer.EndSession"
+
+	claims, err := i.parse(accessToken, TokenTypeAccess)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	sessionToken, err := i.store.SessionToken(ctx, claims.SessionID)
+	if err != nil && !errors.Is(err, ErrInvalidToken) {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := i.store.Revoke(ctx, claims.SessionID); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessionToken, nil
+}
+
+func (i *TokenIssuer) issue(ctx context.Context, userID int64, sessionID, sessionToken string) (TokenPair, error) {
+	now := time.Now()
+	accessExp := now.Add(i.accessTTL)
+	refreshExp := now.Add(i.refreshTTL)
+
+	refreshID, err := randomID()
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	access, err := i.sign(Claims{
+		RegisteredClaims: i.registered(userID, now, accessExp),
+		Type:             TokenTypeAccess,
+		SessionID:        sessionID,
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	registered := i.registered(userID, now, refreshExp)
+	registered.ID = refreshID
+	refresh, err := i.sign(Claims{
+		RegisteredClaims: registered,
+		Type:             TokenTypeRefresh,
+		SessionID:        sessionID,
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	err = i.store.Save(ctx, RefreshToken{
+		ID:           refreshID,
+		SessionID:    sessionID,
+		UserID:       userID,
+		SessionToken: sessionToken,
+		ExpiresAt:    refreshExp,
+	})
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresAt:    accessExp,
+	}, nil
+}
+
+func (i *TokenIssuer) parse(token, typ string) (*Claims, error) {
+	const op = "auth.TokenIssuer.parse"
+
+	var claims Claims
+	_, err := jwt.Parse(token, func(header jwt.Header) (any, error) {
+		if header.Alg != jwt.AlgHS256 {
+			return nil, jwt.ErrUnsupportedAlg
+		}
+		key, ok := i.keys[header.Kid]
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+		return key, nil
+	}, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidToken, err)
+	}
+
+	if err := claims.Validate(time.Now(), 0); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidToken, err)
+	}
+	if claims.Issuer != i.issuer {
+		return nil, fmt.Errorf("%s: %w: unexpected issuer", op, ErrInvalidToken)
+	}
+	if claims.Type != typ {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidTokenType)
+	}
+
+	return &claims, nil
+}
+
+func (i *TokenIssuer) sign(claims Claims) (string, error) {
+	return jwt.SignHS256(i.activeKeyID, i.keys[i.activeKeyID], claims)
+}
+
+func (i *TokenIssuer) registered(userID int64, now, exp time.Time) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Issuer:    i.issuer,
+		Subject:   strconv.FormatInt(userID, 10),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: exp.Unix(),
+	}
+}
+
+func (i *TokenIssuer) userID(claims *Claims) (int64, error) {
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid subject", ErrInvalidToken)
+	}
+	return userID, nil
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testConfig() config.JWTConfig {
+	return config.JWTConfig{
+		Issuer:      "test",
+		AccessTTL:   time.Minute,
+		RefreshTTL:  time.Hour,
+		ActiveKeyID: "k1",
+		Keys:        map[string]string{"k1": "secret-1"},
+	}
+}
+
+func newIssuer(t *testing.T, cfg config.JWTConfig, store RefreshStore) *TokenIssuer {
+	t.Helper()
+
+	issuer, err := NewTokenIssuer(cfg, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return issuer
+}
+
+func TestNewTokenIssuerUnknownActiveKey(t *testing.T) {
+	cfg := testConfig()
+	cfg.ActiveKeyID = "missing"
+
+	if _, err := NewTokenIssuer(cfg, NewMemoryRefreshStore()); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("NewTokenIssuer() error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRefreshStore()
+
+	old := newIssuer(t, testConfig(), store)
+	pair, err := old.IssuePair(ctx, 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := testConfig()
+	rotated.ActiveKeyID = "k2"
+	rotated.Keys = map[string]string{"k1": "secret-1", "k2": "secret-2"}
+	issuer := newIssuer(t, rotated, store)
+
+	if userID, err := issuer.ParseAccessToken(pair.AccessToken); err != nil || userID != 42 {
+		t.Fatalf("token of the previous key: ParseAccessToken() = %d, %v", userID, err)
+	}
+
+	fresh, err := issuer.IssuePair(ctx, 7, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.ParseAccessToken(fresh.AccessToken); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("token of an unknown key: ParseAccessToken() error = %v, want %v", err, ErrUnknownKey)
+	}
+
+	retired := testConfig()
+	retired.ActiveKeyID = "k2"
+	retired.Keys = map[string]string{"k2": "secret-2"}
+	if _, err := newIssuer(t, retired, store).ParseAccessToken(pair.AccessToken); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("token of a removed key: ParseAccessToken() error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestParseAccessTokenRejects(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t, testConfig(), NewMemoryRefreshStore())
+
+	pair, err := issuer.IssuePair(ctx, 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := issuer.ParseAccessToken(pair.RefreshToken); !errors.Is(err, ErrInvalidTokenType) {
+		t.Fatalf("refresh token as access token: error = %v, want %v", err, ErrInvalidTokenType)
+	}
+
+	other := testConfig()
+	other.Issuer = "other"
+	foreign, err := newIssuer(t, other, NewMemoryRefreshStore()).IssuePair(ctx, 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := issuer.ParseAccessToken(foreign.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("foreign issuer: error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	expired := testConfig()
+	expired.AccessTTL = -time.Minute
+	stale, err := newIssuer(t, expired, NewMemoryRefreshStore()).IssuePair(ctx, 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := issuer.ParseAccessToken(stale.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expired token: error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestRefreshTokenDoesNotExposeSession(t *testing.T) {
+	issuer := newIssuer(t, testConfig(), NewMemoryRefreshStore())
+
+	pair, err := issuer.IssuePair(context.Background(), 42, "raw-session-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, token := range []string{pair.AccessToken, pair.RefreshToken} {
+		payload, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(payload), "raw-session-token") {
+			t.Fatalf("token payload exposes the session token: %s", payload)
+		}
+	}
+}
+
+func TestRefreshRotation(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t, testConfig(), NewMemoryRefreshStore())
+
+	pair, err := issuer.IssuePair(ctx, 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	used, err := issuer.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if used.UserID != 42 || used.SessionToken != "session" {
+		t.Fatalf("Refresh() = %+v", used)
+	}
+
+	next, err := issuer.Rotate(ctx, used)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.RefreshToken == pair.RefreshToken {
+		t.Fatal("Rotate() returned the same refresh token")
+	}
+
+	// replaying the used token revokes the whole session, including the rotated token
+	if _, err := issuer.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrRefreshReused) {
+		t.Fatalf("reused token: Refresh() error = %v, want %v", err, ErrRefreshReused)
+	}
+	if _, err := issuer.Refresh(ctx, next.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("token of a revoked session: Refresh() error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestRefreshSurvivesFailedRotation(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t, testConfig(), NewMemoryRefreshStore())
+
+	pair, err := issuer.IssuePair(ctx, 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the request failed before Rotate, e.g. the user service was unavailable
+	if _, err := issuer.Refresh(ctx, pair.RefreshToken); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	used, err := issuer.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("retried Refresh() error = %v", err)
+	}
+	if _, err := issuer.Rotate(ctx, used); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	// a concurrent request holding the same record loses and revokes the session
+	if _, err := issuer.Rotate(ctx, used); !errors.Is(err, ErrRefreshReused) {
+		t.Fatalf("second Rotate() error = %v, want %v", err, ErrRefreshReused)
+	}
+}
+
+func TestRefreshRejectsAccessToken(t *testing.T) {
+	issuer := newIssuer(t, testConfig(), NewMemoryRefreshStore())
+
+	pair, err := issuer.IssuePair(context.Background(), 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := issuer.Refresh(context.Background(), pair.AccessToken); !errors.Is(err, ErrInvalidTokenType) {
+		t.Fatalf("Refresh() error = %v, want %v", err, ErrInvalidTokenType)
+	}
+}
+
+func TestEndSession(t *testing.T) {
+	ctx := context.Background()
+	issuer := newIssuer(t, testConfig(), NewMemoryRefreshStore())
+
+	pair, err := issuer.IssuePair(ctx, 42, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessionToken, err := issuer.EndSession(ctx, pair.AccessToken)
+	if err != nil || sessionToken != "session" {
+		t.Fatalf("EndSession() = %q, %v", sessionToken, err)
+	}
+	if _, err := issuer.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("refresh after logout: error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	sessionToken, err = issuer.EndSession(ctx, pair.AccessToken)
+	if err != nil || sessionToken != "" {
+		t.Fatalf("second EndSession() = %q, %v", sessionToken, err)
+	}
+}
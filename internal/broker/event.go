@@ -0,0 +1,62 @@
+package broker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// SpecVersion is the CloudEvents specification version of every published event.
+const SpecVersion = "1.0"
+
+// Event types. The version suffix changes whenever the data of an event changes
+// incompatibly, consumers subscribe to the versions they understand.
+const (
+	TypeUserSignedUp       = "kz.uniclubs.user.signed_up.v1"
+	TypeUserActivated      = "kz.uniclubs.user.activated.v1"
+	TypeClubCreated        = "kz.uniclubs.club.created.v1"
+	TypeClubApproved       = "kz.uniclubs.club.approved.v1"
+	TypeClubRejected       = "kz.uniclubs.club.rejected.v1"
+	TypeMembershipApproved = "kz.uniclubs.membership.approved.v1"
+	TypeMembershipRejected = "kz.uniclubs.membership.rejected.v1"
+	TypeMembershipAdded    = "kz.uniclubs.membership.added.v1"
+	TypeMembershipLeft     = "kz.uniclubs.membership.left.v1"
+	TypeMembershipRemoved  = "kz.uniclubs.membership.removed.v1"
+)
+
+// Event is a CloudEvents 1.0 event in the structured JSON format.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// NewEvent creates an event with a random ID, data is encoded as JSON.
+func NewEvent(source, eventType, subject string, data any) (Event, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		SpecVersion:     SpecVersion,
+		ID:              hex.EncodeToString(id),
+		Source:          source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            body,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nats-io/nats.go"
+)
+
+// NATSPublisher publishes events in the CloudEvents structured mode to the subject
+// "<prefix>.<event type>", e.g. "uniclubs.kz.uniclubs.club.approved.v1".
+type NATSPublisher struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+// NewNATSPublisher wraps an established connection, the connection is closed by Close.
+func NewNATSPublisher(conn *nats.Conn, prefix string) *NATSPublisher {
+	return &NATSPublisher{conn: conn, prefix: prefix}
+}
+
+// ConnectNATS connects to the NATS server at url and returns a publisher using it.
+func ConnectNATS(url, prefix string) (*NATSPublisher, error) {
+	const op = "broker.ConnectNATS"
+
+	conn, err := nats.Connect(url, nats.Name("uniclubs-gateway"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return NewNATSPublisher(conn, prefix), nil
+}
+
+// Publish sends the event and waits for the server to acknowledge the flush, so a
+// nil error means the server has the message.
+func (p *NATSPublisher) Publish(ctx context.Context, event Event) error {
+	const op = "broker.NATSPublisher.Publish"
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	msg := nats.NewMsg(p.subject(event.Type))
+	msg.Data = body
+	msg.Header.Set("Content-Type", "application/cloudevents+json")
+	msg.Header.Set("ce-id", event.ID)
+	msg.Header.Set("ce-type", event.Type)
+
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := p.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	if err := p.conn.Drain(); err != nil {
+		p.conn.Close()
+		return err
+	}
+	return nil
+}
+
+func (p *NATSPublisher) subject(eventType string) string {
+	if p.prefix == "" {
+		return eventType
+	}
+	return p.prefix + "." + eventType
+}
@@ -0,0 +1,220 @@
+package broker_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/nats-io/nats-server/v2/server"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"io"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testPrefix = "uniclubs"
+
+func runServer(t *testing.T, port int) *server.Server {
+	t.Helper()
+
+	opts := natstest.DefaultTestOptions
+	opts.Port = port
+	srv := natstest.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+func serverPort(srv *server.Server) int {
+	return srv.Addr().(*net.TCPAddr).Port
+}
+
+// subscribe returns a channel receiving every message published with the prefix.
+func subscribe(t *testing.T, url string) <-chan *nats.Msg {
+	t.Helper()
+
+	conn, err := nats.Connect(url, nats.MaxReconnects(-1), nats.ReconnectWait(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(conn.Close)
+
+	msgs := make(chan *nats.Msg, 16)
+	if _, err := conn.ChanSubscribe(testPrefix+".>", msgs); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return msgs
+}
+
+func receive(t *testing.T, msgs <-chan *nats.Msg, timeout time.Duration) *nats.Msg {
+	t.Helper()
+
+	select {
+	case msg := <-msgs:
+		return msg
+	case <-time.After(timeout):
+		t.Fatal("no message received")
+		return nil
+	}
+}
+
+func TestNATSPublisherPublish(t *testing.T) {
+	srv := runServer(t, -1)
+	msgs := subscribe(t, srv.ClientURL())
+
+	publisher, err := broker.ConnectNATS(srv.ClientURL(), testPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+
+	event, err := broker.NewEvent("/uniclubs/gateway", broker.TypeClubApproved, "42", map[string]any{"club_id": 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := publisher.Publish(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := receive(t, msgs, time.Second)
+	if want := testPrefix + "." + broker.TypeClubApproved; msg.Subject != want {
+		t.Errorf("subject = %q, want %q", msg.Subject, want)
+	}
+
+	headers := map[string]string{
+		"Content-Type": "application/cloudevents+json",
+		"ce-id":        event.ID,
+		"ce-type":      broker.TypeClubApproved,
+	}
+	for key, want := range headers {
+		if got := msg.Header.Get(key); got != want {
+			t.Errorf("header %s = %q, want %q", key, got, want)
+		}
+	}
+
+	var got broker.Event
+	if err := json.Unmarshal(msg.Data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.SpecVersion != broker.SpecVersion || got.ID != event.ID || got.Subject != "42" || got.Source != "/uniclubs/gateway" {
+		t.Errorf("event = %+v", got)
+	}
+	if string(got.Data) != `{"club_id":42}` {
+		t.Errorf("data = %s", got.Data)
+	}
+}
+
+func TestOutboxRetriesAfterServerRestart(t *testing.T) {
+	srv := runServer(t, -1)
+	port := serverPort(srv)
+
+	publisher, err := broker.ConnectNATS(srv.ClientURL(), testPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+
+	srv.Shutdown()
+
+	cfg := config.BrokerConfig{
+		Source:         "/uniclubs/gateway",
+		PollInterval:   20 * time.Millisecond,
+		PublishTimeout: 100 * time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+		BatchSize:      10,
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	path := filepath.Join(t.TempDir(), "outbox.json")
+
+	storage, err := broker.NewFileOutboxStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outbox := broker.NewOutbox(storage, publisher, cfg, log)
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		outbox.Run(ctx)
+		close(done)
+	}()
+
+	if err := outbox.Add(context.Background(), broker.TypeClubCreated, "7", map[string]any{"club_id": 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the broker is down, the event stays in the outbox and the failures are recorded
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries, err := storage.Pending(context.Background(), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) == 1 && entries[0].Attempts >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("entries = %+v, want one entry with failed attempts", entries)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// restart the gateway side too, the entry has to survive in the file
+	stop()
+	<-done
+
+	storage, err = broker.NewFileOutboxStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := storage.Pending(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Attempts == 0 || entries[0].LastError == "" {
+		t.Fatalf("entries after reload = %+v", entries)
+	}
+	eventID := entries[0].Event.ID
+
+	srv = runServer(t, port)
+	msgs := subscribe(t, srv.ClientURL())
+
+	outbox = broker.NewOutbox(storage, publisher, cfg, log)
+	ctx, stop = context.WithCancel(context.Background())
+	defer stop()
+	go outbox.Run(ctx)
+
+	// messages buffered by the client while it was reconnecting may arrive as well,
+	// consumers deduplicate by the event id
+	msg := receive(t, msgs, 10*time.Second)
+	if msg.Header.Get("ce-id") != eventID {
+		t.Errorf("ce-id = %q, want %q", msg.Header.Get("ce-id"), eventID)
+	}
+	if want := testPrefix + "." + broker.TypeClubCreated; msg.Subject != want {
+		t.Errorf("subject = %q, want %q", msg.Subject, want)
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for {
+		entries, err := storage.Pending(context.Background(), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("entries = %+v, want the outbox to be empty", entries)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,138 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"log/slog"
+	"time"
+)
+
+// Outbox stores events before they are published and relays them to the broker in
+// the background. Handlers only write to the local outbox, so a broker outage delays
+// events instead of losing them. Events are published in the order they were added
+// and retried until the broker accepts them.
+type Outbox struct {
+	storage        OutboxStorage
+	publisher      Publisher
+	source         string
+	pollInterval   time.Duration
+	publishTimeout time.Duration
+	maxBackoff     time.Duration
+	batchSize      int
+	wake           chan struct{}
+	log            *slog.Logger
+}
+
+func NewOutbox(storage OutboxStorage, publisher Publisher, cfg config.BrokerConfig, log *slog.Logger) *Outbox {
+	return &Outbox{
+		storage:        storage,
+		publisher:      publisher,
+		source:         cfg.Source,
+		pollInterval:   cfg.PollInterval,
+		publishTimeout: cfg.PublishTimeout,
+		maxBackoff:     cfg.MaxBackoff,
+		batchSize:      cfg.BatchSize,
+		wake:           make(chan struct{}, 1),
+		log:            log,
+	}
+}
+
+// Add stores a new event for publishing, subject is the ID of the entity the event is about.
+func (o *Outbox) Add(ctx context.Context, eventType, subject string, data any) error {
+	const op = "broker.Outbox.Add"
+
+	event, err := NewEvent(o.source, eventType, subject, data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := o.storage.Add(ctx, OutboxEntry{Event: event, NextAt: event.Time}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run relays stored events to the publisher until the context is cancelled.
+func (o *Outbox) Run(ctx context.Context) {
+	const op = "broker.Outbox.Run"
+	log := o.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := o.relay(ctx); err != nil {
+			log.Error("failed to relay outbox", logger.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.wake:
+		}
+	}
+}
+
+// relay publishes pending entries in order and stops at the first one that is not
+// due yet or fails, so consumers never see events out of order.
+func (o *Outbox) relay(ctx context.Context) error {
+	for {
+		entries, err := o.storage.Pending(ctx, o.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		for _, entry := range entries {
+			if ctx.Err() != nil || time.Now().Before(entry.NextAt) {
+				return nil
+			}
+
+			if err := o.publish(ctx, entry.Event); err != nil {
+				entry.Attempts++
+				entry.LastError = err.Error()
+				entry.NextAt = time.Now().Add(o.backoff(entry.Attempts))
+				o.log.Warn("failed to publish event",
+					slog.String("event_id", entry.Event.ID),
+					slog.String("type", entry.Event.Type),
+					slog.Int("attempts", entry.Attempts),
+					logger.Err(err),
+				)
+				return o.storage.Update(ctx, entry)
+			}
+
+			if err := o.storage.Remove(ctx, entry.Event.ID); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (o *Outbox) publish(ctx context.Context, event Event) error {
+	ctx, cancel := context.WithTimeout(ctx, o.publishTimeout)
+	defer cancel()
+
+	return o.publisher.Publish(ctx, event)
+}
+
+// backoff doubles the delay after every failed attempt starting from the poll interval.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.pollInterval
+	for i := 1; i < attempts && d < o.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > o.maxBackoff {
+		d = o.maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,152 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jsonfile"
+	"sync"
+	"time"
+)
+
+// OutboxEntry is an event waiting to be published.
+type OutboxEntry struct {
+	Event     Event     `json:"event"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	NextAt    time.Time `json:"next_at"`
+}
+
+// OutboxStorage persists events until they are published.
+type OutboxStorage interface {
+	Add(ctx context.Context, entry OutboxEntry) error
+	// Pending returns at most limit entries in the order they were added.
+	Pending(ctx context.Context, limit int) ([]OutboxEntry, error)
+	// Update replaces the entry with the same event ID, missing entries are ignored.
+	Update(ctx context.Context, entry OutboxEntry) error
+	// Remove deletes the entry with the event ID, missing entries are ignored.
+	Remove(ctx context.Context, eventID string) error
+}
+
+type MemoryOutboxStorage struct {
+	mu      sync.Mutex
+	entries []OutboxEntry
+}
+
+func NewMemoryOutboxStorage() *MemoryOutboxStorage {
+	return &MemoryOutboxStorage{}
+}
+
+func (s *MemoryOutboxStorage) Add(_ context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *MemoryOutboxStorage) Pending(_ context.Context, limit int) ([]OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.entries)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	return append([]OutboxEntry(nil), s.entries[:n]...), nil
+}
+
+func (s *MemoryOutboxStorage) Update(_ context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.entries {
+		if s.entries[i].Event.ID == entry.Event.ID {
+			s.entries[i] = entry
+			break
+		}
+	}
+	return nil
+}
+
+func (s *MemoryOutboxStorage) Remove(_ context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.entries {
+		if s.entries[i].Event.ID == eventID {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// FileOutboxStorage keeps the outbox in memory and writes it to a JSON file on every
+// change, so events that were not published yet survive a restart of the gateway.
+type FileOutboxStorage struct {
+	mu   sync.Mutex
+	path string
+	mem  *MemoryOutboxStorage
+}
+
+// NewFileOutboxStorage loads the outbox from path, a missing file is treated as empty.
+func NewFileOutboxStorage(path string) (*FileOutboxStorage, error) {
+	const op = "broker.NewFileOutboxStorage"
+
+	s := &FileOutboxStorage{path: path, mem: NewMemoryOutboxStorage()}
+
+	if err := jsonfile.Load(path, &s.mem.entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
+
+func (s *FileOutboxStorage) Add(ctx context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Add(ctx, entry); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+func (s *FileOutboxStorage) Pending(ctx context.Context, limit int) ([]OutboxEntry, error) {
+	return s.mem.Pending(ctx, limit)
+}
+
+func (s *FileOutboxStorage) Update(ctx context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Update(ctx, entry); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+func (s *FileOutboxStorage) Remove(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Remove(ctx, eventID); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+// flush atomically replaces the file with the current outbox.
+func (s *FileOutboxStorage) flush(ctx context.Context) error {
+	const op = "broker.FileOutboxStorage.flush"
+
+	entries, err := s.mem.Pending(ctx, 0)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := jsonfile.Save(s.path, entries); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
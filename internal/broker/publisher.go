@@ -0,0 +1,55 @@
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// Publisher sends events to a message broker.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+// memoryPublisherCapacity is the number of recent events kept by MemoryPublisher.
+const memoryPublisherCapacity = 1000
+
+// MemoryPublisher keeps the most recent published events in memory, older events are
+// dropped. It is used when no broker is configured and in local development.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+	// next is the index the next event is written to once events is full.
+	next int
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{events: make([]Event, 0, memoryPublisherCapacity)}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.events) < cap(p.events) {
+		p.events = append(p.events, event)
+		return nil
+	}
+	p.events[p.next] = event
+	p.next = (p.next + 1) % len(p.events)
+	return nil
+}
+
+// Events returns the most recent events published so far, oldest first.
+func (p *MemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]Event, 0, len(p.events))
+	events = append(events, p.events[p.next:]...)
+	return append(events, p.events[:p.next]...)
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,16 @@
+package club
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/grpcjson"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "club.Club"
+
+// invoke calls a club service method that is not part of the generated ClubClient.
+// Request and response are plain structs sent with the JSON codec.
+func (c *Client) invoke(ctx context.Context, method string, in, out any, opts ...grpc.CallOption) error {
+	opts = append(opts, grpcjson.CallOption())
+	return c.cc.Invoke(ctx, "/"+serviceName+"/"+method, in, out, opts...)
+}
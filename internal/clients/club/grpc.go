@@ -15,6 +15,7 @@ import (
 
 type Client struct {
 	clubv1.ClubClient
+	cc  *grpc.ClientConn
 	log *slog.Logger
 }
 
@@ -50,6 +51,7 @@ func New(
 
 	return &Client{
 		ClubClient: clubv1.NewClubClient(cc),
+		cc:         cc,
 		log:        log,
 	}, nil
 }
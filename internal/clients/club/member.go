@@ -0,0 +1,183 @@
+package club
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"sync"
+	"time"
+)
+
+// membershipsConcurrency bounds the parallel GetClubMember calls of GetMemberships.
+const membershipsConcurrency = 8
+
+type RoleObject struct {
+	RoleID      int64    `json:"role_id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	Position    int32    `json:"position"`
+	Color       int32    `json:"color"`
+}
+
+type MemberObject struct {
+	UserID   int64        `json:"user_id"`
+	ClubID   int64        `json:"club_id"`
+	Roles    []RoleObject `json:"roles"`
+	IsOwner  bool         `json:"is_owner"`
+	JoinedAt time.Time    `json:"joined_at"`
+}
+
+type GetClubMemberRequest struct {
+	ClubID int64 `json:"club_id"`
+	UserID int64 `json:"user_id"`
+}
+
+type JoinRequestObject struct {
+	ClubID      int64     `json:"club_id"`
+	ClubName    string    `json:"club_name"`
+	LogoURL     string    `json:"logo_url"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+type ListUserJoinRequestsRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+type ListUserJoinRequestsResponse struct {
+	Requests []JoinRequestObject `json:"requests"`
+}
+
+func (x *ListUserJoinRequestsResponse) GetRequests() []JoinRequestObject {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// GetClubMember returns the membership of the user in the club.
+// Returns codes.NotFound if the user is not a member of the club.
+func (c *Client) GetClubMember(ctx context.Context, in *GetClubMemberRequest, opts ...grpc.CallOption) (*MemberObject, error) {
+	out := new(MemberObject)
+	if err := c.invoke(ctx, "GetClubMember", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMemberships returns the memberships of the user in the clubs, in the order of
+// clubIDs. The entry of a club the user is not a member of is nil. Calls are made in
+// parallel and the first error other than codes.NotFound is returned.
+func (c *Client) GetMemberships(ctx context.Context, userID int64, clubIDs []int64, opts ...grpc.CallOption) ([]*MemberObject, error) {
+	members := make([]*MemberObject, len(clubIDs))
+	errs := make([]error, len(clubIDs))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, membershipsConcurrency)
+	for i, clubID := range clubIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, clubID int64) {
+			defer func() { <-sem; wg.Done() }()
+
+			member, err := c.GetClubMember(ctx, &GetClubMemberRequest{ClubID: clubID, UserID: userID}, opts...)
+			switch {
+			case err == nil:
+				members[i] = member
+			case status.Code(err) != codes.NotFound:
+				errs[i] = err
+			}
+		}(i, clubID)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+// ListUserJoinRequests returns the join requests of the user that are still pending.
+func (c *Client) ListUserJoinRequests(ctx context.Context, in *ListUserJoinRequestsRequest, opts ...grpc.CallOption) (*ListUserJoinRequestsResponse, error) {
+	out := new(ListUserJoinRequestsResponse)
+	if err := c.invoke(ctx, "ListUserJoinRequests", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type RemoveMemberRequest struct {
+	ClubID  int64  `json:"club_id"`
+	UserID  int64  `json:"user_id"`
+	ActorID int64  `json:"actor_id"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type TransferOwnershipRequest struct {
+	ClubID     int64 `json:"club_id"`
+	OwnerID    int64 `json:"owner_id"`
+	NewOwnerID int64 `json:"new_owner_id"`
+}
+
+// RemoveMember removes the user from the club, the reason is passed on to the removed member.
+// Returns codes.NotFound if the user is not a member of the club.
+func (c *Client) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "RemoveMember", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransferOwnership makes another member the owner of the club.
+// Returns codes.FailedPrecondition if the new owner is not a member of the club.
+func (c *Client) TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "TransferOwnership", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type GetClubOwnerRequest struct {
+	ClubID int64 `json:"club_id"`
+}
+
+// GetClubOwner returns the owner membership of the club, for approved and pending clubs alike.
+// Returns codes.NotFound if the club does not exist.
+func (c *Client) GetClubOwner(ctx context.Context, in *GetClubOwnerRequest, opts ...grpc.CallOption) (*MemberObject, error) {
+	out := new(MemberObject)
+	if err := c.invoke(ctx, "GetClubOwner", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type AddMemberRequest struct {
+	ClubID  int64 `json:"club_id"`
+	UserID  int64 `json:"user_id"`
+	ActorID int64 `json:"actor_id"`
+}
+
+// AddMember makes the user a member of the club without a join request.
+// Returns codes.AlreadyExists if the user is already a member.
+func (c *Client) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*MemberObject, error) {
+	out := new(MemberObject)
+	if err := c.invoke(ctx, "AddMember", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InviteMember invites the user to the club, the user becomes a member by accepting it.
+// Returns codes.AlreadyExists if the user is already a member or already invited.
+func (c *Client) InviteMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "InviteMember", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
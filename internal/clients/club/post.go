@@ -0,0 +1,139 @@
+package club
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+type AttachmentObject struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+}
+
+type PostObject struct {
+	PostID      int64              `json:"post_id"`
+	ClubID      int64              `json:"club_id"`
+	AuthorID    int64              `json:"author_id"`
+	Text        string             `json:"text"`
+	Attachments []AttachmentObject `json:"attachments"`
+	Pinned      bool               `json:"pinned"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// ImageUpload is an image sent along with a post, the club service stores it and
+// returns its URL in the post attachments.
+type ImageUpload struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+type CreatePostRequest struct {
+	ClubID   int64         `json:"club_id"`
+	AuthorID int64         `json:"author_id"`
+	Text     string        `json:"text"`
+	Images   []ImageUpload `json:"images,omitempty"`
+}
+
+type UpdatePostRequest struct {
+	ClubID  int64  `json:"club_id"`
+	PostID  int64  `json:"post_id"`
+	ActorID int64  `json:"actor_id"`
+	Text    string `json:"text"`
+	// Images replace the current attachments when "attachments" is in the update mask.
+	Images     []ImageUpload `json:"images,omitempty"`
+	UpdateMask []string      `json:"update_mask"`
+}
+
+type PinPostRequest struct {
+	ClubID  int64 `json:"club_id"`
+	PostID  int64 `json:"post_id"`
+	ActorID int64 `json:"actor_id"`
+	Pinned  bool  `json:"pinned"`
+}
+
+type PostRequest struct {
+	ClubID  int64 `json:"club_id"`
+	PostID  int64 `json:"post_id"`
+	ActorID int64 `json:"actor_id,omitempty"`
+}
+
+type ListClubPostsRequest struct {
+	ClubID int64 `json:"club_id"`
+	// BeforeCreatedAt and BeforePostID return only posts older than the given post,
+	// ordered by (created_at, post_id) descending. Zero values start from the newest post.
+	BeforeCreatedAt time.Time `json:"before_created_at,omitempty"`
+	BeforePostID    int64     `json:"before_post_id,omitempty"`
+	PinnedOnly      bool      `json:"pinned_only,omitempty"`
+	Limit           int32     `json:"limit"`
+}
+
+type ListClubPostsResponse struct {
+	Posts []PostObject `json:"posts"`
+}
+
+func (x *ListClubPostsResponse) GetPosts() []PostObject {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+// CreatePost publishes a post in the club.
+// Returns codes.InvalidArgument if the post has no text and no images.
+func (c *Client) CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*PostObject, error) {
+	out := new(PostObject)
+	if err := c.invoke(ctx, "CreatePost", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPost returns the post of the club.
+// Returns codes.NotFound if the post does not exist in the club.
+func (c *Client) GetPost(ctx context.Context, in *PostRequest, opts ...grpc.CallOption) (*PostObject, error) {
+	out := new(PostObject)
+	if err := c.invoke(ctx, "GetPost", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdatePost updates the fields of the post listed in the update mask: text, attachments.
+func (c *Client) UpdatePost(ctx context.Context, in *UpdatePostRequest, opts ...grpc.CallOption) (*PostObject, error) {
+	out := new(PostObject)
+	if err := c.invoke(ctx, "UpdatePost", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PinPost pins or unpins the post.
+func (c *Client) PinPost(ctx context.Context, in *PinPostRequest, opts ...grpc.CallOption) (*PostObject, error) {
+	out := new(PostObject)
+	if err := c.invoke(ctx, "PinPost", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeletePost deletes the post with its attachments.
+func (c *Client) DeletePost(ctx context.Context, in *PostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "DeletePost", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListClubPosts returns the posts of the club, newest first.
+func (c *Client) ListClubPosts(ctx context.Context, in *ListClubPostsRequest, opts ...grpc.CallOption) (*ListClubPostsResponse, error) {
+	out := new(ListClubPostsResponse)
+	if err := c.invoke(ctx, "ListClubPosts", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,22 @@
+package club
+
+import (
+	"context"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"google.golang.org/grpc"
+)
+
+type UpdateBannerRequest struct {
+	ClubID int64  `json:"club_id"`
+	UserID int64  `json:"user_id"`
+	Banner []byte `json:"banner"`
+}
+
+// UpdateBanner uploads a new banner image for the club and returns the updated club.
+func (c *Client) UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*clubv1.ClubObject, error) {
+	out := new(clubv1.ClubObject)
+	if err := c.invoke(ctx, "UpdateBanner", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+package club
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type ListClubRolesRequest struct {
+	ClubID int64 `json:"club_id"`
+}
+
+type ListClubRolesResponse struct {
+	Roles []RoleObject `json:"roles"`
+}
+
+func (x *ListClubRolesResponse) GetRoles() []RoleObject {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type CreateRoleRequest struct {
+	ClubID      int64    `json:"club_id"`
+	ActorID     int64    `json:"actor_id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	Position    int32    `json:"position"`
+	Color       int32    `json:"color"`
+}
+
+type UpdateRoleRequest struct {
+	ClubID      int64    `json:"club_id"`
+	RoleID      int64    `json:"role_id"`
+	ActorID     int64    `json:"actor_id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	Position    int32    `json:"position"`
+	Color       int32    `json:"color"`
+	// UpdateMask lists the fields to update: name, permissions, position, color.
+	UpdateMask []string `json:"update_mask"`
+}
+
+type RolePosition struct {
+	RoleID   int64 `json:"role_id"`
+	Position int32 `json:"position"`
+}
+
+type ReorderRolesRequest struct {
+	ClubID    int64          `json:"club_id"`
+	ActorID   int64          `json:"actor_id"`
+	Positions []RolePosition `json:"positions"`
+}
+
+type DeleteRoleRequest struct {
+	ClubID  int64 `json:"club_id"`
+	RoleID  int64 `json:"role_id"`
+	ActorID int64 `json:"actor_id"`
+}
+
+type MemberRoleRequest struct {
+	ClubID  int64 `json:"club_id"`
+	UserID  int64 `json:"user_id"`
+	RoleID  int64 `json:"role_id"`
+	ActorID int64 `json:"actor_id"`
+}
+
+// ListClubRoles returns the roles of the club with their ids.
+func (c *Client) ListClubRoles(ctx context.Context, in *ListClubRolesRequest, opts ...grpc.CallOption) (*ListClubRolesResponse, error) {
+	out := new(ListClubRolesResponse)
+	if err := c.invoke(ctx, "ListClubRoles", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) CreateRole(ctx context.Context, in *CreateRoleRequest, opts ...grpc.CallOption) (*RoleObject, error) {
+	out := new(RoleObject)
+	if err := c.invoke(ctx, "CreateRole", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*RoleObject, error) {
+	out := new(RoleObject)
+	if err := c.invoke(ctx, "UpdateRole", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReorderRoles sets the positions of the given roles and returns every role of the club.
+func (c *Client) ReorderRoles(ctx context.Context, in *ReorderRolesRequest, opts ...grpc.CallOption) (*ListClubRolesResponse, error) {
+	out := new(ListClubRolesResponse)
+	if err := c.invoke(ctx, "ReorderRoles", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteRole deletes the role and removes it from every member.
+func (c *Client) DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "DeleteRole", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) AssignRole(ctx context.Context, in *MemberRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "AssignRole", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) RevokeRole(ctx context.Context, in *MemberRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "RevokeRole", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package club
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// Sort fields and orders accepted by SearchClubs.
+const (
+	SortByName        = "name"
+	SortByMemberCount = "member_count"
+	SortByCreatedAt   = "created_at"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// ClubSummaryObject is an approved club as returned by SearchClubs, with the
+// aggregates the search sorts and filters on.
+type ClubSummaryObject struct {
+	ClubID          int64     `json:"club_id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	ClubType        string    `json:"club_type"`
+	LogoURL         string    `json:"logo_url"`
+	BannerURL       string    `json:"banner_url"`
+	OwnerID         int64     `json:"owner_id"`
+	NumberOfMembers int64     `json:"number_of_members"`
+	OpenMembership  bool      `json:"open_membership"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type SearchClubsRequest struct {
+	Query     string   `json:"query,omitempty"`
+	ClubTypes []string `json:"club_types,omitempty"`
+	// OpenMembership filters by whether the club accepts join requests, nil matches both.
+	OpenMembership *bool      `json:"open_membership,omitempty"`
+	MinMembers     int64      `json:"min_members,omitempty"`
+	CreatedAfter   *time.Time `json:"created_after,omitempty"`
+	SortBy         string     `json:"sort_by,omitempty"`
+	SortOrder      string     `json:"sort_order,omitempty"`
+	PageNumber     int32      `json:"page_number"`
+	PageSize       int32      `json:"page_size"`
+}
+
+type PaginationMetadata struct {
+	CurrentPage  int32 `json:"current_page"`
+	PageSize     int32 `json:"page_size"`
+	FirstPage    int32 `json:"first_page"`
+	LastPage     int32 `json:"last_page"`
+	TotalRecords int32 `json:"total_records"`
+}
+
+type SearchClubsResponse struct {
+	Clubs    []ClubSummaryObject `json:"clubs"`
+	Metadata PaginationMetadata  `json:"metadata"`
+}
+
+func (x *SearchClubsResponse) GetClubs() []ClubSummaryObject {
+	if x != nil {
+		return x.Clubs
+	}
+	return nil
+}
+
+func (x *SearchClubsResponse) GetMetadata() PaginationMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return PaginationMetadata{}
+}
+
+// SearchClubs lists approved clubs matching the filters, sorted by SortBy.
+// An empty SortBy keeps the club service default order.
+func (c *Client) SearchClubs(ctx context.Context, in *SearchClubsRequest, opts ...grpc.CallOption) (*SearchClubsResponse, error) {
+	out := new(SearchClubsResponse)
+	if err := c.invoke(ctx, "SearchClubs", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,204 @@
+package event
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/grpcjson"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// ServiceName is the fully qualified gRPC service name of the event service.
+const ServiceName = "event.Event"
+
+// Event statuses.
+const (
+	StatusScheduled = "scheduled"
+	StatusCancelled = "cancelled"
+)
+
+// RSVP statuses. A "going" RSVP to a full event is stored as "waitlisted" and
+// promoted in order once a seat is freed.
+const (
+	RSVPGoing      = "going"
+	RSVPWaitlisted = "waitlisted"
+	RSVPNotGoing   = "not_going"
+)
+
+type EventObject struct {
+	EventID     int64     `json:"event_id"`
+	ClubID      int64     `json:"club_id"`
+	CreatorID   int64     `json:"creator_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	// Capacity is the number of seats, 0 means unlimited.
+	Capacity      int32     `json:"capacity"`
+	Status        string    `json:"status"`
+	CancelReason  string    `json:"cancel_reason,omitempty"`
+	GoingCount    int32     `json:"going_count"`
+	WaitlistCount int32     `json:"waitlist_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type RSVPObject struct {
+	EventID int64  `json:"event_id"`
+	UserID  int64  `json:"user_id"`
+	Status  string `json:"status"`
+	// WaitlistPosition is the 1-based position on the waitlist, 0 if not waitlisted.
+	WaitlistPosition int32     `json:"waitlist_position"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type CreateEventRequest struct {
+	ClubID      int64     `json:"club_id"`
+	CreatorID   int64     `json:"creator_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Capacity    int32     `json:"capacity"`
+}
+
+type GetEventRequest struct {
+	EventID int64 `json:"event_id"`
+}
+
+type UpdateEventRequest struct {
+	EventID     int64     `json:"event_id"`
+	ActorID     int64     `json:"actor_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Capacity    int32     `json:"capacity"`
+	// UpdateMask lists the fields to update: title, description, location, starts_at, ends_at, capacity.
+	UpdateMask []string `json:"update_mask"`
+}
+
+type CancelEventRequest struct {
+	EventID int64  `json:"event_id"`
+	ActorID int64  `json:"actor_id"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type ListEventsRequest struct {
+	// ClubID filters by club, 0 lists events of every club.
+	ClubID int64 `json:"club_id,omitempty"`
+	// From and To bound the event start time, zero values are open ends.
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+	// UpcomingOnly hides cancelled events and events that have already ended.
+	UpcomingOnly bool  `json:"upcoming_only,omitempty"`
+	Page         int32 `json:"page"`
+	PageSize     int32 `json:"page_size"`
+}
+
+type ListEventsResponse struct {
+	Events     []EventObject `json:"events"`
+	TotalCount int32         `json:"total_count"`
+}
+
+func (x *ListEventsResponse) GetEvents() []EventObject {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type RSVPRequest struct {
+	EventID int64 `json:"event_id"`
+	UserID  int64 `json:"user_id"`
+	// Status is RSVPGoing or RSVPNotGoing.
+	Status string `json:"status"`
+}
+
+type GetRSVPRequest struct {
+	EventID int64 `json:"event_id"`
+	UserID  int64 `json:"user_id"`
+}
+
+// CreateEvent schedules a new club event.
+// Returns codes.InvalidArgument if the time range or capacity is invalid.
+func (c *Client) CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*EventObject, error) {
+	out := new(EventObject)
+	if err := c.invoke(ctx, "CreateEvent", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetEvent returns the event with its current RSVP counts.
+// Returns codes.NotFound if the event does not exist.
+func (c *Client) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*EventObject, error) {
+	out := new(EventObject)
+	if err := c.invoke(ctx, "GetEvent", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateEvent updates the fields of the event listed in the update mask. Lowering the
+// capacity moves the latest RSVPs to the waitlist, raising it promotes waitlisted ones.
+// Returns codes.FailedPrecondition if the event is cancelled.
+func (c *Client) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*EventObject, error) {
+	out := new(EventObject)
+	if err := c.invoke(ctx, "UpdateEvent", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CancelEvent cancels the event, RSVPs are kept but no new ones are accepted.
+// Returns codes.FailedPrecondition if the event is already cancelled.
+func (c *Client) CancelEvent(ctx context.Context, in *CancelEventRequest, opts ...grpc.CallOption) (*EventObject, error) {
+	out := new(EventObject)
+	if err := c.invoke(ctx, "CancelEvent", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListEvents returns events ordered by start time.
+func (c *Client) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	out := new(ListEventsResponse)
+	if err := c.invoke(ctx, "ListEvents", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RSVP records the user's answer to the event. Going to a full event puts the user on the waitlist.
+// Returns codes.FailedPrecondition if the event is cancelled or has already started.
+func (c *Client) RSVP(ctx context.Context, in *RSVPRequest, opts ...grpc.CallOption) (*RSVPObject, error) {
+	out := new(RSVPObject)
+	if err := c.invoke(ctx, "RSVP", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRSVP returns the user's RSVP to the event.
+// Returns codes.NotFound if the user has not answered.
+func (c *Client) GetRSVP(ctx context.Context, in *GetRSVPRequest, opts ...grpc.CallOption) (*RSVPObject, error) {
+	out := new(RSVPObject)
+	if err := c.invoke(ctx, "GetRSVP", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) invoke(ctx context.Context, method string, in, out any, opts ...grpc.CallOption) error {
+	opts = append(opts, grpcjson.CallOption())
+	return c.cc.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, opts...)
+}
@@ -0,0 +1,34 @@
+package fake
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"log/slog"
+	"net"
+	"time"
+)
+
+// StartInProcess serves a new fake over an in-memory connection and returns a client
+// dialed to it, stop shuts the server down. It is meant for tests of the client and handlers.
+func StartInProcess(ctx context.Context, log *slog.Logger) (client *event.Client, server *Server, stop func(), err error) {
+	lis := bufconn.Listen(1 << 20)
+
+	gs := grpc.NewServer()
+	server = NewServer()
+	server.Register(gs)
+	go func() { _ = gs.Serve(lis) }()
+
+	client, err = event.New(ctx, log, "passthrough:///bufconn", 5*time.Second, 0,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		gs.Stop()
+		return nil, nil, nil, err
+	}
+
+	return client, server, gs.Stop, nil
+}
@@ -0,0 +1,331 @@
+// Package fake provides an in-memory implementation of the event service contract,
+// used to run the gateway locally and to exercise the event client without the real service.
+package fake
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultPageSize = 20
+
+type eventState struct {
+	event event.EventObject
+	// queue holds the users answering "going" in RSVP order, the first Capacity
+	// of them have a seat and the rest are waitlisted.
+	queue    []int64
+	notGoing map[int64]time.Time
+	answered map[int64]time.Time
+}
+
+// Server is an in-memory event service. It is safe for concurrent use.
+type Server struct {
+	mu     sync.Mutex
+	nextID int64
+	events map[int64]*eventState
+	now    func() time.Time
+}
+
+func NewServer() *Server {
+	return &Server{
+		events: make(map[int64]*eventState),
+		now:    time.Now,
+	}
+}
+
+// Register registers the fake as the event service on the gRPC server.
+// The server must accept the JSON codec, which is registered by importing the event client.
+func (s *Server) Register(gs *grpc.Server) {
+	gs.RegisterService(&grpc.ServiceDesc{
+		ServiceName: event.ServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "CreateEvent", Handler: unary("CreateEvent", s.CreateEvent)},
+			{MethodName: "GetEvent", Handler: unary("GetEvent", s.GetEvent)},
+			{MethodName: "UpdateEvent", Handler: unary("UpdateEvent", s.UpdateEvent)},
+			{MethodName: "CancelEvent", Handler: unary("CancelEvent", s.CancelEvent)},
+			{MethodName: "ListEvents", Handler: unary("ListEvents", s.ListEvents)},
+			{MethodName: "RSVP", Handler: unary("RSVP", s.RSVP)},
+			{MethodName: "GetRSVP", Handler: unary("GetRSVP", s.GetRSVP)},
+		},
+	}, s)
+}
+
+func (s *Server) CreateEvent(_ context.Context, in *event.CreateEventRequest) (*event.EventObject, error) {
+	if in.ClubID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "club_id is required")
+	}
+	if err := validate(in.Title, in.StartsAt, in.EndsAt, in.Capacity); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	state := &eventState{
+		event: event.EventObject{
+			EventID:     s.nextID,
+			ClubID:      in.ClubID,
+			CreatorID:   in.CreatorID,
+			Title:       in.Title,
+			Description: in.Description,
+			Location:    in.Location,
+			StartsAt:    in.StartsAt,
+			EndsAt:      in.EndsAt,
+			Capacity:    in.Capacity,
+			Status:      event.StatusScheduled,
+			CreatedAt:   s.now(),
+		},
+		notGoing: make(map[int64]time.Time),
+		answered: make(map[int64]time.Time),
+	}
+	s.events[state.event.EventID] = state
+
+	return state.snapshot(), nil
+}
+
+func (s *Server) GetEvent(_ context.Context, in *event.GetEventRequest) (*event.EventObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.events[in.EventID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "event not found")
+	}
+	return state.snapshot(), nil
+}
+
+func (s *Server) UpdateEvent(_ context.Context, in *event.UpdateEventRequest) (*event.EventObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.events[in.EventID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "event not found")
+	}
+	if state.event.Status == event.StatusCancelled {
+		return nil, status.Error(codes.FailedPrecondition, "event is cancelled")
+	}
+
+	updated := state.event
+	for _, path := range in.UpdateMask {
+		switch path {
+		case "title":
+			updated.Title = in.Title
+		case "description":
+			updated.Description = in.Description
+		case "location":
+			updated.Location = in.Location
+		case "starts_at":
+			updated.StartsAt = in.StartsAt
+		case "ends_at":
+			updated.EndsAt = in.EndsAt
+		case "capacity":
+			updated.Capacity = in.Capacity
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update_mask path %q", path)
+		}
+	}
+	if err := validate(updated.Title, updated.StartsAt, updated.EndsAt, updated.Capacity); err != nil {
+		return nil, err
+	}
+
+	state.event = updated
+	return state.snapshot(), nil
+}
+
+func (s *Server) CancelEvent(_ context.Context, in *event.CancelEventRequest) (*event.EventObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.events[in.EventID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "event not found")
+	}
+	if state.event.Status == event.StatusCancelled {
+		return nil, status.Error(codes.FailedPrecondition, "event is already cancelled")
+	}
+
+	state.event.Status = event.StatusCancelled
+	state.event.CancelReason = in.Reason
+	return state.snapshot(), nil
+}
+
+func (s *Server) ListEvents(_ context.Context, in *event.ListEventsRequest) (*event.ListEventsResponse, error) {
+	if in.Page < 0 || in.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page and page_size must not be negative")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var events []event.EventObject
+	for _, state := range s.events {
+		e := state.event
+		switch {
+		case in.ClubID != 0 && e.ClubID != in.ClubID:
+			continue
+		case !in.From.IsZero() && e.StartsAt.Before(in.From):
+			continue
+		case !in.To.IsZero() && e.StartsAt.After(in.To):
+			continue
+		case in.UpcomingOnly && (e.Status == event.StatusCancelled || !e.EndsAt.After(now)):
+			continue
+		}
+		events = append(events, *state.snapshot())
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].StartsAt.Equal(events[j].StartsAt) {
+			return events[i].EventID < events[j].EventID
+		}
+		return events[i].StartsAt.Before(events[j].StartsAt)
+	})
+
+	page, size := in.Page, in.PageSize
+	if page == 0 {
+		page = 1
+	}
+	if size == 0 {
+		size = defaultPageSize
+	}
+
+	res := &event.ListEventsResponse{TotalCount: int32(len(events))}
+	start := int((page - 1) * size)
+	if start < len(events) {
+		end := min(start+int(size), len(events))
+		res.Events = events[start:end]
+	}
+	return res, nil
+}
+
+func (s *Server) RSVP(_ context.Context, in *event.RSVPRequest) (*event.RSVPObject, error) {
+	if in.Status != event.RSVPGoing && in.Status != event.RSVPNotGoing {
+		return nil, status.Errorf(codes.InvalidArgument, "status must be %q or %q", event.RSVPGoing, event.RSVPNotGoing)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.events[in.EventID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "event not found")
+	}
+	if state.event.Status == event.StatusCancelled {
+		return nil, status.Error(codes.FailedPrecondition, "event is cancelled")
+	}
+	if !s.now().Before(state.event.StartsAt) {
+		return nil, status.Error(codes.FailedPrecondition, "event has already started")
+	}
+
+	now := s.now()
+	idx := state.indexOf(in.UserID)
+	switch in.Status {
+	case event.RSVPGoing:
+		if idx < 0 {
+			state.queue = append(state.queue, in.UserID)
+			state.answered[in.UserID] = now
+			delete(state.notGoing, in.UserID)
+		}
+	case event.RSVPNotGoing:
+		if idx >= 0 {
+			state.queue = append(state.queue[:idx], state.queue[idx+1:]...)
+		}
+		state.notGoing[in.UserID] = now
+		state.answered[in.UserID] = now
+	}
+
+	return state.rsvp(in.UserID)
+}
+
+func (s *Server) GetRSVP(_ context.Context, in *event.GetRSVPRequest) (*event.RSVPObject, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.events[in.EventID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "event not found")
+	}
+	return state.rsvp(in.UserID)
+}
+
+func (e *eventState) snapshot() *event.EventObject {
+	res := e.event
+	going := len(e.queue)
+	if e.event.Capacity > 0 && going > int(e.event.Capacity) {
+		going = int(e.event.Capacity)
+	}
+	res.GoingCount = int32(going)
+	res.WaitlistCount = int32(len(e.queue) - going)
+	return &res
+}
+
+func (e *eventState) indexOf(userID int64) int {
+	for i, id := range e.queue {
+		if id == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e *eventState) rsvp(userID int64) (*event.RSVPObject, error) {
+	res := &event.RSVPObject{EventID: e.event.EventID, UserID: userID, UpdatedAt: e.answered[userID]}
+
+	if _, ok := e.notGoing[userID]; ok {
+		res.Status = event.RSVPNotGoing
+		return res, nil
+	}
+
+	idx := e.indexOf(userID)
+	switch {
+	case idx < 0:
+		return nil, status.Error(codes.NotFound, "rsvp not found")
+	case e.event.Capacity == 0 || idx < int(e.event.Capacity):
+		res.Status = event.RSVPGoing
+	default:
+		res.Status = event.RSVPWaitlisted
+		res.WaitlistPosition = int32(idx - int(e.event.Capacity) + 1)
+	}
+	return res, nil
+}
+
+func validate(title string, startsAt, endsAt time.Time, capacity int32) error {
+	switch {
+	case strings.TrimSpace(title) == "":
+		return status.Error(codes.InvalidArgument, "title is required")
+	case startsAt.IsZero():
+		return status.Error(codes.InvalidArgument, "starts_at is required")
+	case !endsAt.After(startsAt):
+		return status.Error(codes.InvalidArgument, "ends_at must be after starts_at")
+	case capacity < 0:
+		return status.Error(codes.InvalidArgument, "capacity must not be negative")
+	}
+	return nil
+}
+
+// unary adapts a typed method to a grpc method handler.
+func unary[Req, Res any](method string, fn func(context.Context, *Req) (*Res, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := new(Req)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return fn(ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{FullMethod: "/" + event.ServiceName + "/" + method}
+		return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+			return fn(ctx, req.(*Req))
+		})
+	}
+}
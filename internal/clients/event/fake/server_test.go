@@ -0,0 +1,213 @@
+package fake_test
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event/fake"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func startClient(t *testing.T) *event.Client {
+	t.Helper()
+
+	client, _, stop, err := fake.StartInProcess(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+	return client
+}
+
+func createEvent(t *testing.T, client *event.Client, clubID int64, startsIn time.Duration, capacity int32) *event.EventObject {
+	t.Helper()
+
+	startsAt := time.Now().Add(startsIn).UTC().Truncate(time.Second)
+	res, err := client.CreateEvent(context.Background(), &event.CreateEventRequest{
+		ClubID:    clubID,
+		CreatorID: 1,
+		Title:     "Meeting",
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(time.Hour),
+		Capacity:  capacity,
+	})
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+	return res
+}
+
+func rsvp(t *testing.T, client *event.Client, eventID, userID int64, answer string) *event.RSVPObject {
+	t.Helper()
+
+	res, err := client.RSVP(context.Background(), &event.RSVPRequest{EventID: eventID, UserID: userID, Status: answer})
+	if err != nil {
+		t.Fatalf("RSVP(user %d, %s) error = %v", userID, answer, err)
+	}
+	return res
+}
+
+func TestCapacityAndWaitlistPromotion(t *testing.T) {
+	ctx := context.Background()
+	client := startClient(t)
+	e := createEvent(t, client, 1, 24*time.Hour, 2)
+
+	for _, userID := range []int64{10, 11} {
+		if got := rsvp(t, client, e.EventID, userID, event.RSVPGoing); got.Status != event.RSVPGoing {
+			t.Fatalf("user %d: status = %q, want %q", userID, got.Status, event.RSVPGoing)
+		}
+	}
+	for i, userID := range []int64{12, 13} {
+		got := rsvp(t, client, e.EventID, userID, event.RSVPGoing)
+		if got.Status != event.RSVPWaitlisted || got.WaitlistPosition != int32(i+1) {
+			t.Fatalf("user %d: rsvp = %+v, want waitlisted at %d", userID, got, i+1)
+		}
+	}
+
+	// answering going twice keeps the place in the queue
+	if got := rsvp(t, client, e.EventID, 12, event.RSVPGoing); got.WaitlistPosition != 1 {
+		t.Fatalf("repeated rsvp: waitlist position = %d, want 1", got.WaitlistPosition)
+	}
+
+	rsvp(t, client, e.EventID, 10, event.RSVPNotGoing)
+
+	promoted, err := client.GetRSVP(ctx, &event.GetRSVPRequest{EventID: e.EventID, UserID: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promoted.Status != event.RSVPGoing {
+		t.Fatalf("first waitlisted user after a seat freed up: status = %q, want %q", promoted.Status, event.RSVPGoing)
+	}
+	next, err := client.GetRSVP(ctx, &event.GetRSVPRequest{EventID: e.EventID, UserID: 13})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Status != event.RSVPWaitlisted || next.WaitlistPosition != 1 {
+		t.Fatalf("second waitlisted user: rsvp = %+v, want waitlisted at 1", next)
+	}
+
+	got, err := client.GetEvent(ctx, &event.GetEventRequest{EventID: e.EventID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GoingCount != 2 || got.WaitlistCount != 1 {
+		t.Fatalf("counts = %d going, %d waitlisted, want 2 and 1", got.GoingCount, got.WaitlistCount)
+	}
+
+	// raising the capacity seats the waitlist
+	updated, err := client.UpdateEvent(ctx, &event.UpdateEventRequest{EventID: e.EventID, Capacity: 5, UpdateMask: []string{"capacity"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.GoingCount != 3 || updated.WaitlistCount != 0 {
+		t.Fatalf("after raising capacity: %d going, %d waitlisted, want 3 and 0", updated.GoingCount, updated.WaitlistCount)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx := context.Background()
+	client := startClient(t)
+	e := createEvent(t, client, 1, 24*time.Hour, 0)
+
+	cancelled, err := client.CancelEvent(ctx, &event.CancelEventRequest{EventID: e.EventID, ActorID: 1, Reason: "room unavailable"})
+	if err != nil {
+		t.Fatalf("CancelEvent() error = %v", err)
+	}
+	if cancelled.Status != event.StatusCancelled || cancelled.CancelReason != "room unavailable" {
+		t.Fatalf("CancelEvent() = %+v", cancelled)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"cancel again", func() error {
+			_, err := client.CancelEvent(ctx, &event.CancelEventRequest{EventID: e.EventID})
+			return err
+		}, codes.FailedPrecondition},
+		{"rsvp", func() error {
+			_, err := client.RSVP(ctx, &event.RSVPRequest{EventID: e.EventID, UserID: 10, Status: event.RSVPGoing})
+			return err
+		}, codes.FailedPrecondition},
+		{"update", func() error {
+			_, err := client.UpdateEvent(ctx, &event.UpdateEventRequest{EventID: e.EventID, Title: "x", UpdateMask: []string{"title"}})
+			return err
+		}, codes.FailedPrecondition},
+		{"unknown event", func() error {
+			_, err := client.CancelEvent(ctx, &event.CancelEventRequest{EventID: 999})
+			return err
+		}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.want {
+				t.Fatalf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListEventsFilters(t *testing.T) {
+	ctx := context.Background()
+	client := startClient(t)
+
+	past := createEvent(t, client, 1, -48*time.Hour, 0)
+	soon := createEvent(t, client, 1, 24*time.Hour, 0)
+	later := createEvent(t, client, 1, 72*time.Hour, 0)
+	otherClub := createEvent(t, client, 2, 24*time.Hour, 0)
+	cancelled := createEvent(t, client, 1, 48*time.Hour, 0)
+	if _, err := client.CancelEvent(ctx, &event.CancelEventRequest{EventID: cancelled.EventID}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		req       *event.ListEventsRequest
+		wantIDs   []int64
+		wantTotal int32
+	}{
+		{"all sorted by start", &event.ListEventsRequest{}, []int64{past.EventID, soon.EventID, otherClub.EventID, cancelled.EventID, later.EventID}, 5},
+		{"club", &event.ListEventsRequest{ClubID: 2}, []int64{otherClub.EventID}, 1},
+		{"upcoming skips past and cancelled", &event.ListEventsRequest{ClubID: 1, UpcomingOnly: true}, []int64{soon.EventID, later.EventID}, 2},
+		{"from and to", &event.ListEventsRequest{ClubID: 1, From: soon.StartsAt, To: cancelled.StartsAt}, []int64{soon.EventID, cancelled.EventID}, 2},
+		{"second page", &event.ListEventsRequest{ClubID: 1, Page: 2, PageSize: 3}, []int64{later.EventID}, 4},
+		{"page past the end", &event.ListEventsRequest{Page: 9, PageSize: 3}, nil, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := client.ListEvents(ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []int64
+			for _, e := range res.GetEvents() {
+				ids = append(ids, e.EventID)
+			}
+			if !equalIDs(ids, tt.wantIDs) || res.GetTotalCount() != tt.wantTotal {
+				t.Fatalf("ListEvents() = %v (total %d), want %v (total %d)", ids, res.GetTotalCount(), tt.wantIDs, tt.wantTotal)
+			}
+		})
+	}
+
+	if _, err := client.ListEvents(ctx, &event.ListEventsRequest{Page: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("negative page: code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package notification
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/grpcjson"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// ServiceName is the fully qualified gRPC service name of the notification service.
+const ServiceName = "notification.Notification"
+
+// Notification types emitted by the gateway.
+const (
+	TypeJoinRequestApproved = "join_request_approved"
+	TypeJoinRequestRejected = "join_request_rejected"
+	TypeClubApproved        = "club_approved"
+)
+
+type NotificationObject struct {
+	NotificationID int64             `json:"notification_id"`
+	UserID         int64             `json:"user_id"`
+	Type           string            `json:"type"`
+	Title          string            `json:"title"`
+	Body           string            `json:"body"`
+	Data           map[string]string `json:"data,omitempty"`
+	ReadAt         *time.Time        `json:"read_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+type SendNotificationRequest struct {
+	UserID int64             `json:"user_id"`
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Data   map[string]string `json:"data,omitempty"`
+}
+
+type ListNotificationsRequest struct {
+	UserID     int64 `json:"user_id"`
+	UnreadOnly bool  `json:"unread_only,omitempty"`
+	Page       int32 `json:"page"`
+	PageSize   int32 `json:"page_size"`
+}
+
+type ListNotificationsResponse struct {
+	Notifications []NotificationObject `json:"notifications"`
+	TotalCount    int32                `json:"total_count"`
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []NotificationObject {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type SetReadRequest struct {
+	UserID         int64 `json:"user_id"`
+	NotificationID int64 `json:"notification_id"`
+	Read           bool  `json:"read"`
+}
+
+type UserRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+type MarkAllReadResponse struct {
+	Updated int32 `json:"updated"`
+}
+
+type UnreadCountResponse struct {
+	Count int32 `json:"count"`
+}
+
+// SendNotification stores the notification in the user's inbox.
+func (c *Client) SendNotification(ctx context.Context, in *SendNotificationRequest, opts ...grpc.CallOption) (*NotificationObject, error) {
+	out := new(NotificationObject)
+	if err := c.invoke(ctx, "SendNotification", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListNotifications returns the user's notifications, newest first.
+func (c *Client) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	out := new(ListNotificationsResponse)
+	if err := c.invoke(ctx, "ListNotifications", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetRead marks the notification as read or unread.
+// Returns codes.NotFound if the notification does not belong to the user.
+func (c *Client) SetRead(ctx context.Context, in *SetReadRequest, opts ...grpc.CallOption) (*NotificationObject, error) {
+	out := new(NotificationObject)
+	if err := c.invoke(ctx, "SetRead", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarkAllRead marks every unread notification of the user as read.
+func (c *Client) MarkAllRead(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*MarkAllReadResponse, error) {
+	out := new(MarkAllReadResponse)
+	if err := c.invoke(ctx, "MarkAllRead", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnreadCount returns the number of unread notifications of the user.
+func (c *Client) UnreadCount(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*UnreadCountResponse, error) {
+	out := new(UnreadCountResponse)
+	if err := c.invoke(ctx, "UnreadCount", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *Client) invoke(ctx context.Context, method string, in, out any, opts ...grpc.CallOption) error {
+	opts = append(opts, grpcjson.CallOption())
+	return c.cc.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, opts...)
+}
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type ResendActivationRequest struct {
+	Email string `json:"email"`
+}
+
+type ActivateUserByCodeRequest struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+type GetActivationStatusRequest struct {
+	Email string `json:"email"`
+}
+
+type GetActivationStatusResponse struct {
+	Activated bool `json:"activated"`
+}
+
+// ResendActivation issues a new activation token and code and emails them.
+// Returns codes.NotFound if there is no account with the email and
+// codes.FailedPrecondition if the account is already activated.
+func (c *Client) ResendActivation(ctx context.Context, in *ResendActivationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "ResendActivation", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActivateUserByCode activates the account with the 6-digit code from the activation email.
+// Returns codes.NotFound if the code is wrong or expired.
+func (c *Client) ActivateUserByCode(ctx context.Context, in *ActivateUserByCodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "ActivateUserByCode", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetActivationStatus reports whether the account with the email is activated.
+// Returns codes.NotFound if there is no account with the email.
+func (c *Client) GetActivationStatus(ctx context.Context, in *GetActivationStatusRequest, opts ...grpc.CallOption) (*GetActivationStatusResponse, error) {
+	out := new(GetActivationStatusResponse)
+	if err := c.invoke(ctx, "GetActivationStatus", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"context"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type RequestEmailChangeRequest struct {
+	UserID   int64  `json:"user_id"`
+	Password string `json:"password"`
+	NewEmail string `json:"new_email"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+type RevokeSessionsRequest struct {
+	UserID int64 `json:"user_id"`
+	// ExceptSessionToken is kept if it belongs to the user, empty revokes every session.
+	ExceptSessionToken string `json:"except_session_token"`
+}
+
+// RequestEmailChange checks the password and sends a verification link to the new address
+// through the notification service. Returns codes.PermissionDenied if the password is wrong
+// and codes.AlreadyExists if the new address is taken.
+func (c *Client) RequestEmailChange(ctx context.Context, in *RequestEmailChangeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "RequestEmailChange", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConfirmEmailChange applies the pending email change of the token and returns the updated user.
+// Returns codes.NotFound if the token is unknown or expired.
+func (c *Client) ConfirmEmailChange(ctx context.Context, in *ConfirmEmailChangeRequest, opts ...grpc.CallOption) (*userv1.UserObject, error) {
+	out := new(userv1.UserObject)
+	if err := c.invoke(ctx, "ConfirmEmailChange", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevokeSessions logs the user out everywhere except the given session.
+func (c *Client) RevokeSessions(ctx context.Context, in *RevokeSessionsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "RevokeSessions", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
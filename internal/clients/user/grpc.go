@@ -15,6 +15,7 @@ import (
 
 type Client struct {
 	userv1.UserClient
+	cc  *grpc.ClientConn
 	log *slog.Logger
 }
 
@@ -50,6 +51,7 @@ func New(
 
 	return &Client{
 		UserClient: userv1.NewUserClient(cc),
+		cc:         cc,
 		log:        log,
 	}, nil
 }
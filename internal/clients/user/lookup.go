@@ -0,0 +1,57 @@
+package user
+
+import (
+	"context"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const (
+	lookupPageSize = 100
+	// lookupMaxPages bounds the search results checked for an exact match.
+	lookupMaxPages = 20
+)
+
+// FindUserByEmail returns the user with exactly the email, compared case-insensitively.
+// SearchUsers is fuzzy, so its results are paged through until the exact match.
+// Returns codes.NotFound if there is no such user and codes.ResourceExhausted if the
+// match is not within the first lookupMaxPages pages.
+func (c *Client) FindUserByEmail(ctx context.Context, email string, opts ...grpc.CallOption) (*userv1.UserObject, error) {
+	return c.findUser(ctx, email, func(u *userv1.UserObject) bool {
+		return strings.EqualFold(u.GetEmail(), email)
+	}, opts...)
+}
+
+// FindUserByBarcode returns the user with exactly the barcode.
+// Returns codes.NotFound if there is no such user and codes.ResourceExhausted if the
+// match is not within the first lookupMaxPages pages.
+func (c *Client) FindUserByBarcode(ctx context.Context, barcode string, opts ...grpc.CallOption) (*userv1.UserObject, error) {
+	return c.findUser(ctx, barcode, func(u *userv1.UserObject) bool {
+		return u.GetBarcode() == barcode
+	}, opts...)
+}
+
+func (c *Client) findUser(ctx context.Context, query string, match func(*userv1.UserObject) bool, opts ...grpc.CallOption) (*userv1.UserObject, error) {
+	for page := int32(1); page <= lookupMaxPages; page++ {
+		res, err := c.SearchUsers(ctx, &userv1.SearchUsersRequest{Query: query, PageNumber: page, PageSize: lookupPageSize}, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range res.GetUsers() {
+			if match(u) {
+				return u, nil
+			}
+		}
+
+		if len(res.GetUsers()) < lookupPageSize || page >= res.GetMetadata().GetLastPage() {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+	}
+
+	// the results were not checked to the end, the user may still exist
+	return nil, status.Error(codes.ResourceExhausted, "too many users match the query")
+}
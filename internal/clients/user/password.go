@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type RequestPasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+type ChangePasswordRequest struct {
+	UserID          int64  `json:"user_id"`
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// RequestPasswordReset sends a password reset link to the email.
+// Returns codes.NotFound if there is no account with the email.
+func (c *Client) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "RequestPasswordReset", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResetPassword sets a new password using the token from the reset email.
+// Returns codes.NotFound if the token is unknown or expired.
+func (c *Client) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "ResetPassword", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChangePassword sets a new password after checking the current one.
+// Returns codes.PermissionDenied if the current password is wrong.
+func (c *Client) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "ChangePassword", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type VerifyPasswordRequest struct {
+	UserID   int64  `json:"user_id"`
+	Password string `json:"password"`
+}
+
+// VerifyPassword checks the password of the user without starting a session.
+// Returns codes.PermissionDenied if the password is wrong.
+func (c *Client) VerifyPassword(ctx context.Context, in *VerifyPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	if err := c.invoke(ctx, "VerifyPassword", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,35 @@
+package user
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"time"
+)
+
+type ExtendSessionRequest struct {
+	SessionToken string `json:"session_token"`
+	// TTLSeconds is the new lifetime of the session counted from now.
+	TTLSeconds int64 `json:"ttl_seconds"`
+}
+
+type ExtendSessionResponse struct {
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// GetExpiresAt returns the new session expiry, the zero time if unknown.
+func (x *ExtendSessionResponse) GetExpiresAt() time.Time {
+	if x == nil || x.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(x.ExpiresAt, 0)
+}
+
+// ExtendSession moves the expiry of the session to ttl from now.
+// Returns codes.NotFound if the session is unknown or already expired.
+func (c *Client) ExtendSession(ctx context.Context, in *ExtendSessionRequest, opts ...grpc.CallOption) (*ExtendSessionResponse, error) {
+	out := new(ExtendSessionResponse)
+	if err := c.invoke(ctx, "ExtendSession", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
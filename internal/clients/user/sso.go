@@ -0,0 +1,24 @@
+package user
+
+import (
+	"context"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"google.golang.org/grpc"
+)
+
+type ExternalLoginRequest struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	// Email is verified by the identity provider, it links an existing account on first login.
+	Email string `json:"email"`
+}
+
+// ExternalLogin creates a session for the user linked to the external identity.
+// Returns codes.NotFound if no account exists for the identity or its email.
+func (c *Client) ExternalLogin(ctx context.Context, in *ExternalLoginRequest, opts ...grpc.CallOption) (*userv1.LoginResponse, error) {
+	out := new(userv1.LoginResponse)
+	if err := c.invoke(ctx, "ExternalLogin", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
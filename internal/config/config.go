@@ -11,14 +11,126 @@ import (
 type Config struct {
 	Env             string `yaml:"env" env:"ENV" env-default:"local"`
 	HTTPServer      `yaml:"http_server"`
-	Clients         ClientsConfig `yaml:"clients"`
-	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"10s"`
+	Clients         ClientsConfig    `yaml:"clients"`
+	Session         SessionConfig    `yaml:"session"`
+	CSRF            CSRFConfig       `yaml:"csrf"`
+	JWT             JWTConfig        `yaml:"jwt"`
+	APIKeys         APIKeysConfig    `yaml:"api_keys"`
+	OIDC            OIDCConfig       `yaml:"oidc"`
+	Realtime        RealtimeConfig   `yaml:"realtime"`
+	Webhooks        WebhookConfig    `yaml:"webhooks"`
+	Broker          BrokerConfig     `yaml:"broker"`
+	Attendance      AttendanceConfig `yaml:"attendance"`
+	ShutdownTimeout time.Duration    `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"10s"`
 }
 
 type HTTPServer struct {
 	Address     string        `yaml:"address" env:"HTTP_ADDRESS" env-default:"localhost:5000"`
 	Timeout     time.Duration `yaml:"timeout" env:"HTTP_TIMEOUT" env-default:"4s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" env-default:"60s"`
+	// AllowedOrigins are the browser origins allowed by CORS and trusted by the CSRF check.
+	AllowedOrigins []string `yaml:"allowed_origins" env:"HTTP_ALLOWED_ORIGINS" env-default:"http://localhost:3000"`
+}
+
+type SessionConfig struct {
+	CookieName string `yaml:"cookie_name" env:"SESSION_COOKIE_NAME" env-default:"session_token"`
+	Domain     string `yaml:"domain" env:"SESSION_COOKIE_DOMAIN"`
+	Path       string `yaml:"path" env:"SESSION_COOKIE_PATH" env-default:"/"`
+	Secure     bool   `yaml:"secure" env:"SESSION_COOKIE_SECURE" env-default:"false"`
+	// SameSite is one of "lax", "strict" or "none"; "none" requires Secure.
+	SameSite         string        `yaml:"same_site" env:"SESSION_COOKIE_SAME_SITE" env-default:"lax"`
+	Lifetime         time.Duration `yaml:"lifetime" env:"SESSION_LIFETIME" env-default:"24h"`
+	RememberLifetime time.Duration `yaml:"remember_lifetime" env:"SESSION_REMEMBER_LIFETIME" env-default:"720h"`
+	// RenewBefore is how close to expiry the cookie must be before SessionAuthMiddleware renews it.
+	RenewBefore time.Duration `yaml:"renew_before" env:"SESSION_RENEW_BEFORE" env-default:"6h"`
+	// Secret signs the cookie that remembers the session expiry.
+	Secret string `yaml:"secret" env:"SESSION_SECRET"`
+}
+
+type CSRFConfig struct {
+	Enabled    bool   `yaml:"enabled" env:"CSRF_ENABLED" env-default:"true"`
+	Secret     string `yaml:"secret" env:"CSRF_SECRET"`
+	CookieName string `yaml:"cookie_name" env:"CSRF_COOKIE_NAME" env-default:"csrf_token"`
+	HeaderName string `yaml:"header_name" env:"CSRF_HEADER_NAME" env-default:"X-CSRF-Token"`
+	// ExemptPaths are path prefixes that skip the CSRF check, e.g. webhooks called by other services.
+	ExemptPaths []string `yaml:"exempt_paths" env:"CSRF_EXEMPT_PATHS"`
+}
+
+type JWTConfig struct {
+	Enabled    bool          `yaml:"enabled" env:"JWT_ENABLED" env-default:"false"`
+	Issuer     string        `yaml:"issuer" env:"JWT_ISSUER" env-default:"uniclubs-api-gateway"`
+	AccessTTL  time.Duration `yaml:"access_ttl" env:"JWT_ACCESS_TTL" env-default:"15m"`
+	RefreshTTL time.Duration `yaml:"refresh_ttl" env:"JWT_REFRESH_TTL" env-default:"720h"`
+	// ActiveKeyID is the kid used to sign new tokens, other Keys are accepted for verification
+	// only, so a key can be rotated by adding a new one and switching ActiveKeyID.
+	ActiveKeyID string            `yaml:"active_key_id" env:"JWT_ACTIVE_KEY_ID"`
+	Keys        map[string]string `yaml:"keys" env:"JWT_KEYS"`
+}
+
+type APIKeysConfig struct {
+	// Storage is "memory" or "file".
+	Storage  string `yaml:"storage" env:"API_KEYS_STORAGE" env-default:"memory"`
+	FilePath string `yaml:"file_path" env:"API_KEYS_FILE_PATH" env-default:"./api_keys.json"`
+}
+
+type OIDCConfig struct {
+	Enabled      bool     `yaml:"enabled" env:"OIDC_ENABLED" env-default:"false"`
+	ProviderName string   `yaml:"provider_name" env:"OIDC_PROVIDER_NAME" env-default:"university"`
+	IssuerURL    string   `yaml:"issuer_url" env:"OIDC_ISSUER_URL"`
+	ClientID     string   `yaml:"client_id" env:"OIDC_CLIENT_ID"`
+	ClientSecret string   `yaml:"client_secret" env:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string   `yaml:"redirect_url" env:"OIDC_REDIRECT_URL" env-default:"http://localhost:5000/auth/oidc/callback"`
+	Scopes       []string `yaml:"scopes" env:"OIDC_SCOPES" env-default:"openid,email,profile"`
+	// PostLoginRedirect is the frontend URL the browser is sent to after a successful login.
+	PostLoginRedirect string `yaml:"post_login_redirect" env:"OIDC_POST_LOGIN_REDIRECT" env-default:"http://localhost:3000"`
+	// StateSecret signs the short-lived login state cookie, random per process if empty.
+	StateSecret    string `yaml:"state_secret" env:"OIDC_STATE_SECRET"`
+	FirstNameClaim string `yaml:"first_name_claim" env:"OIDC_FIRST_NAME_CLAIM" env-default:"given_name"`
+	LastNameClaim  string `yaml:"last_name_claim" env:"OIDC_LAST_NAME_CLAIM" env-default:"family_name"`
+	BarcodeClaim   string `yaml:"barcode_claim" env:"OIDC_BARCODE_CLAIM" env-default:"student_id"`
+}
+
+type RealtimeConfig struct {
+	// BufferSize is the number of recent events kept for Last-Event-ID replay.
+	BufferSize int `yaml:"buffer_size" env:"REALTIME_BUFFER_SIZE" env-default:"1024"`
+	// ClientBuffer is the number of queued events after which a slow client is disconnected.
+	ClientBuffer      int           `yaml:"client_buffer" env:"REALTIME_CLIENT_BUFFER" env-default:"64"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"REALTIME_HEARTBEAT_INTERVAL" env-default:"25s"`
+}
+
+type WebhookConfig struct {
+	// MaxAttempts is the number of delivery attempts before a delivery is moved to the dead letters.
+	MaxAttempts    int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" env-default:"8"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env:"WEBHOOKS_INITIAL_BACKOFF" env-default:"10s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env:"WEBHOOKS_MAX_BACKOFF" env-default:"1h"`
+	Timeout        time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" env-default:"10s"`
+	Workers        int           `yaml:"workers" env:"WEBHOOKS_WORKERS" env-default:"4"`
+	PollInterval   time.Duration `yaml:"poll_interval" env:"WEBHOOKS_POLL_INTERVAL" env-default:"1s"`
+	// Storage is "memory" or "file".
+	Storage  string `yaml:"storage" env:"WEBHOOKS_STORAGE" env-default:"memory"`
+	FilePath string `yaml:"file_path" env:"WEBHOOKS_FILE_PATH" env-default:"./webhooks.json"`
+}
+
+type BrokerConfig struct {
+	// Driver is "memory" or "nats".
+	Driver        string `yaml:"driver" env:"BROKER_DRIVER" env-default:"memory"`
+	NATSURL       string `yaml:"nats_url" env:"BROKER_NATS_URL" env-default:"nats://localhost:4222"`
+	SubjectPrefix string `yaml:"subject_prefix" env:"BROKER_SUBJECT_PREFIX" env-default:"uniclubs"`
+	// Source is the CloudEvents source attribute of every published event.
+	Source string `yaml:"source" env:"BROKER_SOURCE" env-default:"/uniclubs/gateway"`
+	// OutboxStorage is "memory" or "file".
+	OutboxStorage  string        `yaml:"outbox_storage" env:"BROKER_OUTBOX_STORAGE" env-default:"memory"`
+	OutboxFilePath string        `yaml:"outbox_file_path" env:"BROKER_OUTBOX_FILE_PATH" env-default:"./outbox.json"`
+	PollInterval   time.Duration `yaml:"poll_interval" env:"BROKER_POLL_INTERVAL" env-default:"1s"`
+	PublishTimeout time.Duration `yaml:"publish_timeout" env:"BROKER_PUBLISH_TIMEOUT" env-default:"5s"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env:"BROKER_MAX_BACKOFF" env-default:"1m"`
+	BatchSize      int           `yaml:"batch_size" env:"BROKER_BATCH_SIZE" env-default:"100"`
+}
+
+type AttendanceConfig struct {
+	// Storage is "memory" or "file".
+	Storage  string `yaml:"storage" env:"ATTENDANCE_STORAGE" env-default:"memory"`
+	FilePath string `yaml:"file_path" env:"ATTENDANCE_FILE_PATH" env-default:"./attendance.json"`
 }
 
 type ClientsConfig struct {
@@ -32,6 +144,16 @@ type ClientsConfig struct {
 		Timeout      time.Duration `yaml:"timeout" env:"CLUB_SERVICE_TIMEOUT"`
 		RetriesCount int           `yaml:"retries_count" env:"CLUB_SERVICE_RETRIES_COUNT"`
 	} `yaml:"club"`
+	Event struct {
+		Address      string        `yaml:"address" env:"EVENT_SERVICE_ADDRESS"`
+		Timeout      time.Duration `yaml:"timeout" env:"EVENT_SERVICE_TIMEOUT"`
+		RetriesCount int           `yaml:"retries_count" env:"EVENT_SERVICE_RETRIES_COUNT"`
+	} `yaml:"event"`
+	Notification struct {
+		Address      string        `yaml:"address" env:"NOTIFICATION_SERVICE_ADDRESS"`
+		Timeout      time.Duration `yaml:"timeout" env:"NOTIFICATION_SERVICE_TIMEOUT"`
+		RetriesCount int           `yaml:"retries_count" env:"NOTIFICATION_SERVICE_RETRIES_COUNT"`
+	} `yaml:"notification"`
 }
 
 func MustLoad() *Config {
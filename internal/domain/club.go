@@ -2,28 +2,40 @@ package domain
 
 import (
 	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
 	"time"
 )
 
+// Club is the club returned by the API. OwnerID, NumOFMembers and OpenMembership are
+// nil when the source the club was mapped from does not carry them.
 type Club struct {
-	ID           int64
-	Name         string
-	OwnerID      *int64
-	Description  string
-	ClubType     string
-	LogoURL      string
-	BannerURL    string
-	NumOFMembers *int64
-	CreatedAt    time.Time
-	Roles        []Role
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	OwnerID        *int64    `json:"owner_id"`
+	Description    string    `json:"description"`
+	ClubType       string    `json:"club_type"`
+	LogoURL        string    `json:"logo_url"`
+	BannerURL      string    `json:"banner_url"`
+	NumOFMembers   *int64    `json:"number_of_members"`
+	OpenMembership *bool     `json:"open_membership,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	Roles          []Role    `json:"roles"`
 }
 
+// Role is a club role. ID is zero for roles embedded in club service messages that
+// do not carry it.
 type Role struct {
-	ID          int
-	Name        string
-	Permissions []string
-	Position    int32
-	Color       int32
+	ID          int64    `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	Position    int32    `json:"position"`
+	Color       int32    `json:"color"`
+}
+
+// ClubRequest is a club waiting for approval together with its owner.
+type ClubRequest struct {
+	Club  *Club   `json:"club"`
+	Owner *Member `json:"owner"`
 }
 
 type Member struct {
@@ -33,43 +45,88 @@ type Member struct {
 	LastName  string `json:"last_name"`
 	Barcode   string `json:"barcode"`
 	AvatarURL string `json:"avatar_url"`
-	Roles     []Role
+	Roles     []Role `json:"roles"`
 }
 
 func ClubObjectToClub(clubObject *clubv1.ClubObject) *Club {
-	roles := make([]Role, len(clubObject.GetRoles()))
-	for i, role := range clubObject.GetRoles() {
-		roles[i] = Role{
-			Name:        role.GetName(),
-			Permissions: role.GetPermissions(),
-			Position:    role.GetPosition(),
-			Color:       role.GetColor(),
-		}
+	numOfMembers := clubObject.GetNumberOfMembers()
+
+	return &Club{
+		ID:           clubObject.GetClubId(),
+		Name:         clubObject.GetName(),
+		Description:  clubObject.GetDescription(),
+		ClubType:     clubObject.GetClubType(),
+		LogoURL:      clubObject.GetLogoUrl(),
+		BannerURL:    clubObject.GetBannerUrl(),
+		NumOFMembers: &numOfMembers,
+		CreatedAt:    clubObject.GetCreatedAt().AsTime(),
+		Roles:        mapRoleArr(clubObject.GetRoles()),
 	}
+}
+
+// NotActivatedClubToClubRequest maps a club waiting for approval, the owner is
+// carried by the message so the club gets its OwnerID.
+func NotActivatedClubToClubRequest(item *clubv1.NotActivatedClubsList) ClubRequest {
+	c := ClubObjectToClub(item.GetClubs())
+
+	var owner *Member
+	if item.GetOwner() != nil {
+		owner = UserObjectToMember(item.GetOwner())
+		c.OwnerID = &owner.ID
+	}
+
+	return ClubRequest{Club: c, Owner: owner}
+}
+
+func MapNotActivatedClubArrToClubRequestArr(items []*clubv1.NotActivatedClubsList) []ClubRequest {
+	requests := make([]ClubRequest, len(items))
+	for i, item := range items {
+		requests[i] = NotActivatedClubToClubRequest(item)
+	}
+
+	return requests
+}
+
+func MapClubObjArrToClubArr(clubObjects []*clubv1.ClubObject) []*Club {
+	clubs := make([]*Club, len(clubObjects))
+	for i, c := range clubObjects {
+		clubs[i] = ClubObjectToClub(c)
+	}
+
+	return clubs
+}
+
+// ClubSummaryObjectToClub maps a search result, it carries the owner and member
+// count but not the roles of the club.
+func ClubSummaryObjectToClub(summary *club.ClubSummaryObject) *Club {
+	ownerID := summary.OwnerID
+	numOfMembers := summary.NumberOfMembers
+	openMembership := summary.OpenMembership
 
 	return &Club{
-		ID:          clubObject.GetClubId(),
-		Name:        clubObject.GetName(),
-		Description: clubObject.GetDescription(),
-		ClubType:    clubObject.GetClubType(),
-		LogoURL:     clubObject.GetLogoUrl(),
-		BannerURL:   clubObject.GetBannerUrl(),
-		CreatedAt:   clubObject.GetCreatedAt().AsTime(),
-		Roles:       roles,
+		ID:             summary.ClubID,
+		Name:           summary.Name,
+		OwnerID:        &ownerID,
+		Description:    summary.Description,
+		ClubType:       summary.ClubType,
+		LogoURL:        summary.LogoURL,
+		BannerURL:      summary.BannerURL,
+		NumOFMembers:   &numOfMembers,
+		OpenMembership: &openMembership,
+		CreatedAt:      summary.CreatedAt,
 	}
 }
 
-func UserObjectToMember(userObject *clubv1.UserObject) *Member {
-	roles := make([]Role, len(userObject.GetRole()))
-	for i, role := range userObject.GetRole() {
-		roles[i] = Role{
-			Name:        role.GetName(),
-			Permissions: role.GetPermissions(),
-			Position:    role.GetPosition(),
-			Color:       role.GetColor(),
-		}
+func MapClubSummaryObjArrToClubArr(summaries []club.ClubSummaryObject) []*Club {
+	clubs := make([]*Club, len(summaries))
+	for i := range summaries {
+		clubs[i] = ClubSummaryObjectToClub(&summaries[i])
 	}
 
+	return clubs
+}
+
+func UserObjectToMember(userObject *clubv1.UserObject) *Member {
 	return &Member{
 		ID:        userObject.GetUserId(),
 		Email:     userObject.GetEmail(),
@@ -77,7 +134,7 @@ func UserObjectToMember(userObject *clubv1.UserObject) *Member {
 		LastName:  userObject.GetLastName(),
 		Barcode:   userObject.GetBarcode(),
 		AvatarURL: userObject.GetAvatarUrl(),
-		Roles:     roles,
+		Roles:     mapRoleArr(userObject.GetRole()),
 	}
 }
 
@@ -89,3 +146,17 @@ func MapUserObjArrToMemberArr(ur []*clubv1.UserObject) []*Member {
 
 	return members
 }
+
+func mapRoleArr(roleObjects []*clubv1.Role) []Role {
+	roles := make([]Role, len(roleObjects))
+	for i, role := range roleObjects {
+		roles[i] = Role{
+			Name:        role.GetName(),
+			Permissions: role.GetPermissions(),
+			Position:    role.GetPosition(),
+			Color:       role.GetColor(),
+		}
+	}
+
+	return roles
+}
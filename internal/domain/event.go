@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	"time"
+)
+
+type Event struct {
+	ID            int64     `json:"id"`
+	ClubID        int64     `json:"club_id"`
+	CreatorID     int64     `json:"creator_id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Location      string    `json:"location"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	Capacity      int32     `json:"capacity"`
+	SeatsLeft     *int32    `json:"seats_left"`
+	Status        string    `json:"status"`
+	CancelReason  string    `json:"cancel_reason,omitempty"`
+	GoingCount    int32     `json:"going_count"`
+	WaitlistCount int32     `json:"waitlist_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type RSVP struct {
+	EventID          int64     `json:"event_id"`
+	UserID           int64     `json:"user_id"`
+	Status           string    `json:"status"`
+	WaitlistPosition int32     `json:"waitlist_position,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// EventObjectToEvent maps the event, SeatsLeft is nil for events without a capacity limit.
+func EventObjectToEvent(e *event.EventObject) *Event {
+	res := &Event{
+		ID:            e.EventID,
+		ClubID:        e.ClubID,
+		CreatorID:     e.CreatorID,
+		Title:         e.Title,
+		Description:   e.Description,
+		Location:      e.Location,
+		StartsAt:      e.StartsAt,
+		EndsAt:        e.EndsAt,
+		Capacity:      e.Capacity,
+		Status:        e.Status,
+		CancelReason:  e.CancelReason,
+		GoingCount:    e.GoingCount,
+		WaitlistCount: e.WaitlistCount,
+		CreatedAt:     e.CreatedAt,
+	}
+	if e.Capacity > 0 {
+		left := max(e.Capacity-e.GoingCount, 0)
+		res.SeatsLeft = &left
+	}
+	return res
+}
+
+func MapEventObjArrToDomain(events []event.EventObject) []*Event {
+	res := make([]*Event, len(events))
+	for i := range events {
+		res[i] = EventObjectToEvent(&events[i])
+	}
+	return res
+}
+
+func RSVPObjectToRSVP(r *event.RSVPObject) *RSVP {
+	return &RSVP{
+		EventID:          r.EventID,
+		UserID:           r.UserID,
+		Status:           r.Status,
+		WaitlistPosition: r.WaitlistPosition,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+var createdAt = time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC)
+
+func testRoleObjects() []*clubv1.Role {
+	return []*clubv1.Role{
+		{Name: "president", Permissions: []string{"manage_members", "manage_events"}, Position: 10, Color: 0xff0000},
+		{Name: "member", Permissions: []string{}, Position: 0, Color: 0},
+	}
+}
+
+func testClubObject() *clubv1.ClubObject {
+	return &clubv1.ClubObject{
+		ClubId:          7,
+		Name:            "Chess Club",
+		Description:     "Weekly games & \"blitz\" <tournaments>",
+		ClubType:        "academic",
+		LogoUrl:         "https://cdn.example.com/logo.png",
+		BannerUrl:       "https://cdn.example.com/banner.png",
+		NumberOfMembers: 42,
+		CreatedAt:       timestamppb.New(createdAt),
+		Roles:           testRoleObjects(),
+	}
+}
+
+func testUserObject() *clubv1.UserObject {
+	return &clubv1.UserObject{
+		UserId:    3,
+		Email:     "aru@example.com",
+		FirstName: "Aru",
+		LastName:  "Man",
+		Barcode:   "210103",
+		AvatarUrl: "https://cdn.example.com/avatar.png",
+		Role:      testRoleObjects(),
+	}
+}
+
+// TestGolden pins the JSON of the DTOs returned by the API, clients depend on the
+// field names and on which fields are null or omitted. Run with -update after an
+// intended change.
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		dto  any
+	}{
+		{"club", ClubObjectToClub(testClubObject())},
+		{"club_empty", ClubObjectToClub(&clubv1.ClubObject{})},
+		{"club_summary", ClubSummaryObjectToClub(&club.ClubSummaryObject{
+			ClubID:          7,
+			Name:            "Chess Club",
+			ClubType:        "academic",
+			OwnerID:         3,
+			NumberOfMembers: 42,
+			OpenMembership:  true,
+			CreatedAt:       createdAt,
+		})},
+		{"club_request", NotActivatedClubToClubRequest(&clubv1.NotActivatedClubsList{
+			Clubs: testClubObject(),
+			Owner: testUserObject(),
+		})},
+		{"club_request_without_owner", NotActivatedClubToClubRequest(&clubv1.NotActivatedClubsList{
+			Clubs: testClubObject(),
+		})},
+		{"member", UserObjectToMember(testUserObject())},
+		{"role", RoleObjectToRole(club.RoleObject{
+			RoleID:      5,
+			Name:        "treasurer",
+			Permissions: []string{"manage_finances"},
+			Position:    4,
+			Color:       0x00ff00,
+		})},
+		{"roles_embedded", mapRoleArr(testRoleObjects())},
+		{"pagination", ClubPaginationToDomain(&clubv1.PaginationMetadata{
+			CurrentPage:  2,
+			PageSize:     20,
+			FirstPage:    1,
+			LastPage:     3,
+			TotalRecords: 55,
+		})},
+		{"pagination_from_total", NewPagination(2, 20, 55)},
+		{"pagination_empty", NewPagination(1, 20, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.MarshalIndent(tt.dto, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			path := filepath.Join("testdata", tt.name+".golden.json")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s does not match:\n got: %s\nwant: %s", path, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"sort"
+	"time"
+)
+
+// Club permissions carried by Role.Permissions.
+const (
+	PermissionManageMembers = "manage_members"
+	PermissionEditClub      = "edit_club"
+	PermissionManageRoles   = "manage_roles"
+	PermissionManageEvents  = "manage_events"
+	PermissionManagePosts   = "manage_posts"
+)
+
+var (
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrRoleHierarchy     = errors.New("role hierarchy violation")
+)
+
+// AllPermissions lists every club permission, owners implicitly hold all of them.
+var AllPermissions = []string{
+	PermissionManageMembers,
+	PermissionEditClub,
+	PermissionManageRoles,
+	PermissionManageEvents,
+	PermissionManagePosts,
+}
+
+type Membership struct {
+	Club        *Club     `json:"club"`
+	Roles       []Role    `json:"roles"`
+	Permissions []string  `json:"permissions"`
+	IsOwner     bool      `json:"is_owner"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+type JoinRequest struct {
+	ClubID      int64     `json:"club_id"`
+	ClubName    string    `json:"club_name"`
+	LogoURL     string    `json:"logo_url"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// EffectivePermissions returns the sorted union of the permissions of the given roles.
+func EffectivePermissions(roles []Role) []string {
+	set := make(map[string]struct{})
+	for _, role := range roles {
+		for _, p := range role.Permissions {
+			set[p] = struct{}{}
+		}
+	}
+
+	permissions := make([]string, 0, len(set))
+	for p := range set {
+		permissions = append(permissions, p)
+	}
+	sort.Strings(permissions)
+
+	return permissions
+}
+
+func RoleObjectToRole(role club.RoleObject) Role {
+	return Role{
+		ID:          role.RoleID,
+		Name:        role.Name,
+		Permissions: role.Permissions,
+		Position:    role.Position,
+		Color:       role.Color,
+	}
+}
+
+func MemberObjectToMembership(c *Club, member *club.MemberObject) Membership {
+	roles := MapRoleObjArrToRoleArr(member.Roles)
+
+	permissions := EffectivePermissions(roles)
+	if member.IsOwner {
+		permissions = AllPermissions
+	}
+
+	return Membership{
+		Club:        c,
+		Roles:       roles,
+		Permissions: permissions,
+		IsOwner:     member.IsOwner,
+		JoinedAt:    member.JoinedAt,
+	}
+}
+
+func MapJoinRequestObjArrToDomain(requests []club.JoinRequestObject) []JoinRequest {
+	res := make([]JoinRequest, len(requests))
+	for i, r := range requests {
+		res[i] = JoinRequest{
+			ClubID:      r.ClubID,
+			ClubName:    r.ClubName,
+			LogoURL:     r.LogoURL,
+			RequestedAt: r.RequestedAt,
+		}
+	}
+	return res
+}
+
+func MapRoleObjArrToRoleArr(roleObjects []club.RoleObject) []Role {
+	roles := make([]Role, len(roleObjects))
+	for i, role := range roleObjects {
+		roles[i] = RoleObjectToRole(role)
+	}
+	return roles
+}
+
+// ClubAccess is what the caller may do in a club. Roles with a higher Position rank higher.
+type ClubAccess struct {
+	ClubID   int64
+	UserID   int64
+	IsAdmin  bool
+	IsOwner  bool
+	IsMember bool
+	Roles    []Role
+}
+
+// ClubAccessFromMember builds the access of a club member, IsAdmin is left to the caller.
+func ClubAccessFromMember(userID int64, member *club.MemberObject) ClubAccess {
+	return ClubAccess{
+		ClubID:   member.ClubID,
+		UserID:   userID,
+		IsOwner:  member.IsOwner,
+		IsMember: true,
+		Roles:    MapRoleObjArrToRoleArr(member.Roles),
+	}
+}
+
+// Can reports whether the caller holds the permission. Owners and global admins hold every permission.
+func (a ClubAccess) Can(permission string) bool {
+	if a.IsAdmin || a.IsOwner {
+		return true
+	}
+	for _, role := range a.Roles {
+		for _, p := range role.Permissions {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HighestPosition returns the position of the caller's highest role, -1 if they have none.
+func (a ClubAccess) HighestPosition() int32 {
+	highest := int32(-1)
+	for _, role := range a.Roles {
+		if role.Position > highest {
+			highest = role.Position
+		}
+	}
+	return highest
+}
+
+// CanManageRole checks that the caller may create, edit, assign, revoke or delete a role
+// with the position and permissions: the role must rank below the caller's highest role
+// and the caller must hold every permission themselves. Owners and global admins may manage any role.
+func (a ClubAccess) CanManageRole(position int32, permissions []string) error {
+	for _, p := range permissions {
+		if !IsPermission(p) {
+			return fmt.Errorf("%w: %s", ErrUnknownPermission, p)
+		}
+	}
+
+	if a.IsAdmin || a.IsOwner {
+		return nil
+	}
+
+	if position >= a.HighestPosition() {
+		return fmt.Errorf("%w: the role must be positioned below your highest role", ErrRoleHierarchy)
+	}
+
+	for _, p := range permissions {
+		if !a.Can(p) {
+			return fmt.Errorf("%w: you cannot grant the %s permission you do not have", ErrRoleHierarchy, p)
+		}
+	}
+
+	return nil
+}
+
+func IsPermission(permission string) bool {
+	for _, p := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/notification"
+	"time"
+)
+
+type Notification struct {
+	ID        int64             `json:"id"`
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`
+	Read      bool              `json:"read"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func NotificationObjectToNotification(n *notification.NotificationObject) *Notification {
+	return &Notification{
+		ID:        n.NotificationID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func MapNotificationObjArrToDomain(ns []notification.NotificationObject) []*Notification {
+	res := make([]*Notification, len(ns))
+	for i := range ns {
+		res[i] = NotificationObjectToNotification(&ns[i])
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+)
+
+// Pagination is the metadata of page-numbered lists.
+type Pagination struct {
+	CurrentPage  int32 `json:"current_page"`
+	PageSize     int32 `json:"page_size"`
+	FirstPage    int32 `json:"first_page"`
+	LastPage     int32 `json:"last_page"`
+	TotalRecords int32 `json:"total_records"`
+}
+
+// NewPagination builds the metadata of a page from the total number of records, for
+// services that only return the total. An empty result has empty metadata.
+func NewPagination(page, pageSize, totalRecords int32) Pagination {
+	if totalRecords == 0 || pageSize <= 0 {
+		return Pagination{}
+	}
+
+	return Pagination{
+		CurrentPage:  page,
+		PageSize:     pageSize,
+		FirstPage:    1,
+		LastPage:     (totalRecords + pageSize - 1) / pageSize,
+		TotalRecords: totalRecords,
+	}
+}
+
+func ClubPaginationToDomain(metadata *clubv1.PaginationMetadata) Pagination {
+	return Pagination{
+		CurrentPage:  metadata.GetCurrentPage(),
+		PageSize:     metadata.GetPageSize(),
+		FirstPage:    metadata.GetFirstPage(),
+		LastPage:     metadata.GetLastPage(),
+		TotalRecords: metadata.GetTotalRecords(),
+	}
+}
+
+func UserPaginationToDomain(metadata *userv1.SearchUsersMetadata) Pagination {
+	return Pagination{
+		CurrentPage:  metadata.GetCurrentPage(),
+		PageSize:     metadata.GetPageSize(),
+		FirstPage:    metadata.GetFirstPage(),
+		LastPage:     metadata.GetLastPage(),
+		TotalRecords: metadata.GetTotalRecords(),
+	}
+}
+
+func SearchPaginationToDomain(metadata club.PaginationMetadata) Pagination {
+	return Pagination{
+		CurrentPage:  metadata.CurrentPage,
+		PageSize:     metadata.PageSize,
+		FirstPage:    metadata.FirstPage,
+		LastPage:     metadata.LastPage,
+		TotalRecords: metadata.TotalRecords,
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Attachment struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+}
+
+type Post struct {
+	ID          int64        `json:"id"`
+	ClubID      int64        `json:"club_id"`
+	AuthorID    int64        `json:"author_id"`
+	Text        string       `json:"text"`
+	Attachments []Attachment `json:"attachments"`
+	Pinned      bool         `json:"pinned"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// PostCursor points at the last post of a page, the next page starts right after it
+// in (created_at, id) descending order.
+type PostCursor struct {
+	CreatedAt time.Time
+	PostID    int64
+}
+
+// Encode returns the opaque cursor string: base64url("<created_at unix nanos>,<post id>").
+func (c PostCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d,%d", c.CreatedAt.UnixNano(), c.PostID)))
+}
+
+// DecodePostCursor parses a cursor returned by PostCursor.Encode.
+func DecodePostCursor(s string) (PostCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return PostCursor{}, ErrInvalidCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return PostCursor{}, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return PostCursor{}, ErrInvalidCursor
+	}
+	postID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return PostCursor{}, ErrInvalidCursor
+	}
+
+	return PostCursor{CreatedAt: time.Unix(0, n).UTC(), PostID: postID}, nil
+}
+
+// NewerThan reports whether post a comes before post b in feed order.
+func NewerThan(a, b *club.PostObject) bool {
+	if a.CreatedAt.Equal(b.CreatedAt) {
+		return a.PostID > b.PostID
+	}
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+func PostObjectToPost(p *club.PostObject) *Post {
+	attachments := make([]Attachment, len(p.Attachments))
+	for i, a := range p.Attachments {
+		attachments[i] = Attachment{URL: a.URL, ContentType: a.ContentType}
+	}
+
+	return &Post{
+		ID:          p.PostID,
+		ClubID:      p.ClubID,
+		AuthorID:    p.AuthorID,
+		Text:        p.Text,
+		Attachments: attachments,
+		Pinned:      p.Pinned,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func MapPostObjArrToDomain(posts []club.PostObject) []*Post {
+	res := make([]*Post, len(posts))
+	for i := range posts {
+		res[i] = PostObjectToPost(&posts[i])
+	}
+	return res
+}
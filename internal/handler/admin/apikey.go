@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"errors"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/apikey"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type apiKeyResponse struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	OwnerID   int64      `json:"owner_id"`
+	Scopes    []string   `json:"scopes"`
+	RateLimit int        `json:"rate_limit"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+func toAPIKeyResponse(key apikey.Key) apiKeyResponse {
+	return apiKeyResponse{
+		ID:        key.ID,
+		Name:      key.Name,
+		OwnerID:   key.OwnerID,
+		Scopes:    key.Scopes,
+		RateLimit: key.RateLimit,
+		ExpiresAt: key.ExpiresAt,
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	const op = "AdminHandler.CreateAPIKey"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input struct {
+		Name      string     `json:"name" binding:"required"`
+		OwnerID   int64      `json:"owner_id"`
+		Scopes    []string   `json:"scopes" binding:"required,min=1"`
+		RateLimit int        `json:"rate_limit" binding:"min=0"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	ownerID := input.OwnerID
+	if ownerID == 0 {
+		ownerID = userIDFromCtx.(int64)
+	}
+
+	plaintext, key, err := h.apiKeys.Create(c, apikey.CreateParams{
+		Name:      input.Name,
+		OwnerID:   ownerID,
+		Scopes:    input.Scopes,
+		RateLimit: input.RateLimit,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, apikey.ErrInvalidScope):
+			log.Warn("invalid scope", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unknown scope", "allowed_scopes": apikey.Scopes})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Info("api key created", slog.String("key_id", key.ID), slog.Int64("owner_id", ownerID))
+
+	// the plaintext key is shown only once
+	c.JSON(http.StatusCreated, gin.H{"api_key": plaintext, "key": toAPIKeyResponse(key)})
+}
+
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	const op = "AdminHandler.ListAPIKeys"
+	log := h.log.With(slog.String("op", op))
+
+	keys, err := h.apiKeys.List(c)
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]apiKeyResponse, len(keys))
+	for i, key := range keys {
+		res[i] = toAPIKeyResponse(key)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"keys": res})
+}
+
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	const op = "AdminHandler.RevokeAPIKey"
+	log := h.log.With(slog.String("op", op))
+
+	id := c.Param("id")
+
+	err := h.apiKeys.Revoke(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, apikey.ErrNotFound):
+			log.Warn("api key not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": apikey.ErrNotFound.Error()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Info("api key revoked", slog.String("key_id", id))
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/apikey"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/webhook"
+	"log/slog"
+)
+
+type Handler struct {
+	apiKeys  *apikey.Service
+	webhooks *webhook.Service
+	log      *slog.Logger
+}
+
+// New creates and returns a new Admin Handler instance
+// Parameters:
+//   - apiKeys: A *apikey.Service managing API keys.
+//   - webhooks: A *webhook.Service managing webhook endpoints and deliveries.
+//   - log: A *slog.Logger used for logging messages and errors.
+//
+// Returns:
+//   - A Handler struct that encapsulates the provided services and logger.
+func New(apiKeys *apikey.Service, webhooks *webhook.Service, log *slog.Logger) Handler {
+	return Handler{
+		apiKeys:  apiKeys,
+		webhooks: webhooks,
+		log:      log,
+	}
+}
@@ -0,0 +1,200 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/webhook"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type webhookEndpointResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Events      []string  `json:"events"`
+	CreatedBy   int64     `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func toWebhookEndpointResponse(endpoint webhook.Endpoint) webhookEndpointResponse {
+	return webhookEndpointResponse{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      endpoint.Events,
+		CreatedBy:   endpoint.CreatedBy,
+		CreatedAt:   endpoint.CreatedAt,
+	}
+}
+
+type webhookDeliveryResponse struct {
+	ID         string          `json:"id"`
+	EndpointID string          `json:"endpoint_id"`
+	EventID    string          `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Payload    json.RawMessage `json:"payload"`
+	Status     string          `json:"status"`
+	Attempts   int             `json:"attempts"`
+	LastStatus int             `json:"last_status,omitempty"`
+	LastError  string          `json:"last_error,omitempty"`
+	NextAt     time.Time       `json:"next_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+func toWebhookDeliveryResponse(delivery webhook.Delivery) webhookDeliveryResponse {
+	return webhookDeliveryResponse{
+		ID:         delivery.ID,
+		EndpointID: delivery.EndpointID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Payload:    delivery.Payload,
+		Status:     delivery.Status,
+		Attempts:   delivery.Attempts,
+		LastStatus: delivery.LastStatus,
+		LastError:  delivery.LastError,
+		NextAt:     delivery.NextAt,
+		CreatedAt:  delivery.CreatedAt,
+		UpdatedAt:  delivery.UpdatedAt,
+	}
+}
+
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	const op = "AdminHandler.CreateWebhook"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input struct {
+		URL         string   `json:"url" binding:"required"`
+		Description string   `json:"description"`
+		Events      []string `json:"events" binding:"required,min=1"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.webhooks.Register(c, webhook.RegisterParams{
+		URL:         input.URL,
+		Description: input.Description,
+		Events:      input.Events,
+		CreatedBy:   userIDFromCtx.(int64),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, webhook.ErrInvalidURL):
+			log.Warn("invalid url", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "url must be an absolute http or https url"})
+		case errors.Is(err, webhook.ErrInvalidEvent):
+			log.Warn("invalid event", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unknown event type", "allowed_events": append(webhook.Events, webhook.EventAll)})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Info("webhook registered", slog.String("webhook_id", endpoint.ID))
+
+	// the signing secret is shown only once
+	c.JSON(http.StatusCreated, gin.H{"secret": endpoint.Secret, "webhook": toWebhookEndpointResponse(endpoint)})
+}
+
+func (h *Handler) ListWebhooks(c *gin.Context) {
+	const op = "AdminHandler.ListWebhooks"
+	log := h.log.With(slog.String("op", op))
+
+	endpoints, err := h.webhooks.Endpoints(c)
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]webhookEndpointResponse, len(endpoints))
+	for i, endpoint := range endpoints {
+		res[i] = toWebhookEndpointResponse(endpoint)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": res})
+}
+
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	const op = "AdminHandler.DeleteWebhook"
+	log := h.log.With(slog.String("op", op))
+
+	id := c.Param("id")
+
+	err := h.webhooks.Unregister(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, webhook.ErrNotFound):
+			log.Warn("webhook not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Info("webhook deleted", slog.String("webhook_id", id))
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	const op = "AdminHandler.ListDeadLetters"
+	log := h.log.With(slog.String("op", op))
+
+	deliveries, err := h.webhooks.DeadLetters(c)
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]webhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = toWebhookDeliveryResponse(delivery)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": res})
+}
+
+func (h *Handler) ReplayDelivery(c *gin.Context) {
+	const op = "AdminHandler.ReplayDelivery"
+	log := h.log.With(slog.String("op", op))
+
+	id := c.Param("id")
+
+	delivery, err := h.webhooks.Replay(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, webhook.ErrNotFound):
+			log.Warn("delivery not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "delivery not found"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	log.Info("webhook delivery replayed", slog.String("delivery_id", id))
+
+	c.JSON(http.StatusAccepted, gin.H{"delivery": toWebhookDeliveryResponse(delivery)})
+}
@@ -0,0 +1,299 @@
+package club
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/attendance"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/realtime"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxRosterPages bounds the ListClubMembers pages read while opening a session.
+const maxRosterPages = 100
+
+var attendanceHeader = []string{"name", "email", "barcode", "status", "checked_in_at", "scans"}
+
+// OpenAttendanceHandler opens an attendance session for a club meeting. The roster is
+// the club members at this moment, members who join later are scanned as non-members.
+func (h *Handler) OpenAttendanceHandler(c *gin.Context) {
+	const op = "ClubHandler.OpenAttendanceHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	var input struct {
+		Title string `json:"title" binding:"required,max=200"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roster, err := h.attendanceRoster(c, access.ClubID)
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	session, err := h.attendance.Open(c, attendance.OpenParams{
+		ClubID:   access.ClubID,
+		Title:    strings.TrimSpace(input.Title),
+		OpenedBy: access.UserID,
+		Roster:   roster,
+	})
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("attendance session opened", slog.Int64("club_id", access.ClubID), slog.String("session_id", session.ID), slog.Int("roster", len(session.Roster)))
+
+	c.JSON(http.StatusCreated, gin.H{"session": session, "counts": attendance.CountScans(session, nil)})
+}
+
+func (h *Handler) ListAttendanceHandler(c *gin.Context) {
+	const op = "ClubHandler.ListAttendanceHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	sessions, err := h.attendance.Sessions(c, access.ClubID)
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// GetAttendanceHandler returns the session with its live counts, and with
+// scans=true every scan made so far.
+func (h *Handler) GetAttendanceHandler(c *gin.Context) {
+	const op = "ClubHandler.GetAttendanceHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	session, scans, err := h.attendance.Scans(c, access.ClubID, c.Param("sessionID"))
+	if err != nil {
+		abortAttendance(c, log, err)
+		return
+	}
+
+	res := gin.H{"session": session, "counts": attendance.CountScans(session, scans)}
+	if c.Query("scans") == "true" {
+		res["scans"] = scans
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CheckInHandler records a scanned barcode. A scan is always answered with 200, the
+// status in the result tells a check-in from a duplicate scan and from a non-member.
+func (h *Handler) CheckInHandler(c *gin.Context) {
+	const op = "ClubHandler.CheckInHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	var input struct {
+		Barcode string `json:"barcode" binding:"required,max=64"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessionID := c.Param("sessionID")
+	result, err := h.attendance.CheckIn(c, access.ClubID, sessionID, input.Barcode, access.UserID)
+	if err != nil {
+		abortAttendance(c, log, err)
+		return
+	}
+
+	h.publish(realtime.AttendanceTopic(access.ClubID), realtime.TypeAttendanceCheckedIn, gin.H{
+		"session_id": sessionID,
+		"result":     result,
+	})
+
+	c.JSON(http.StatusOK, gin.H{"result": result})
+}
+
+func (h *Handler) CloseAttendanceHandler(c *gin.Context) {
+	const op = "ClubHandler.CloseAttendanceHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	session, err := h.attendance.Close(c, access.ClubID, c.Param("sessionID"))
+	if err != nil {
+		abortAttendance(c, log, err)
+		return
+	}
+
+	log.Info("attendance session closed", slog.Int64("club_id", access.ClubID), slog.String("session_id", session.ID))
+
+	c.JSON(http.StatusOK, gin.H{"session": session})
+}
+
+// ExportAttendanceHandler writes a CSV with a row for every roster member, present or
+// absent, followed by a row for every non-member barcode that was scanned.
+func (h *Handler) ExportAttendanceHandler(c *gin.Context) {
+	const op = "ClubHandler.ExportAttendanceHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	session, scans, err := h.attendance.Scans(c, access.ClubID, c.Param("sessionID"))
+	if err != nil {
+		abortAttendance(c, log, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="club-%d-attendance-%s.csv"`, access.ClubID, session.ID))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	if err := writeAttendance(newCSVWriter(c.Writer), session, scans); err != nil {
+		log.Error("attendance export cut short", slog.String("session_id", session.ID), logger.Err(err))
+		return
+	}
+}
+
+func writeAttendance(w rowWriter, session attendance.Session, scans []attendance.Scan) error {
+	first := make(map[string]attendance.Scan)
+	count := make(map[string]int)
+	var nonMembers []string
+	for _, scan := range scans {
+		if _, ok := first[scan.Barcode]; !ok {
+			first[scan.Barcode] = scan
+			if !scan.Member {
+				nonMembers = append(nonMembers, scan.Barcode)
+			}
+		}
+		count[scan.Barcode]++
+	}
+
+	if err := w.WriteRow(attendanceHeader); err != nil {
+		return err
+	}
+	for _, a := range session.Roster {
+		row := []string{strings.TrimSpace(a.FirstName + " " + a.LastName), a.Email, a.Barcode, "absent", "", "0"}
+		if scan, ok := first[a.Barcode]; ok {
+			row[3], row[4], row[5] = "present", scan.At.Format(time.RFC3339), fmt.Sprint(count[a.Barcode])
+		}
+		if err := w.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	for _, barcode := range nonMembers {
+		row := []string{"", "", barcode, attendance.StatusNotMember, first[barcode].At.Format(time.RFC3339), fmt.Sprint(count[barcode])}
+		if err := w.WriteRow(row); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+// attendanceRoster lists the club members that have a barcode.
+func (h *Handler) attendanceRoster(ctx context.Context, clubID int64) ([]attendance.Attendee, error) {
+	var roster []attendance.Attendee
+	for pageNumber := int32(1); pageNumber <= maxRosterPages; pageNumber++ {
+		page, err := h.clbClient.ListClubMembers(ctx, &clubv1.ListClubMembersRequest{
+			ClubId:     clubID,
+			PageNumber: pageNumber,
+			PageSize:   exportPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range page.GetUsers() {
+			if member.GetBarcode() == "" {
+				continue
+			}
+			roster = append(roster, attendance.Attendee{
+				UserID:    member.GetUserId(),
+				Barcode:   member.GetBarcode(),
+				FirstName: member.GetFirstName(),
+				LastName:  member.GetLastName(),
+				Email:     member.GetEmail(),
+			})
+		}
+
+		last := page.GetMetadata().GetLastPage()
+		if len(page.GetUsers()) == 0 || (last > 0 && pageNumber >= last) || (last == 0 && len(page.GetUsers()) < exportPageSize) {
+			break
+		}
+	}
+
+	return roster, nil
+}
+
+func abortAttendance(c *gin.Context, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, attendance.ErrNotFound):
+		log.Warn("attendance session not found", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": attendance.ErrNotFound.Error()})
+	case errors.Is(err, attendance.ErrClosed):
+		log.Warn("attendance session closed", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": attendance.ErrClosed.Error()})
+	case errors.Is(err, attendance.ErrInvalidBarcode):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": attendance.ErrInvalidBarcode.Error()})
+	default:
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}
+}
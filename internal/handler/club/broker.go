@@ -0,0 +1,68 @@
+package club
+
+import (
+	"context"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"log/slog"
+)
+
+// emit stores a domain event about the subject (an entity ID, may be empty) in the outbox, it is published to the broker in the background.
+// Storing is local and only fails on outbox storage errors, which are logged.
+func (h *Handler) emit(eventType, subject string, data map[string]any) {
+	if h.outbox == nil {
+		return
+	}
+
+	const op = "ClubHandler.emit"
+	log := h.log.With(slog.String("op", op), slog.String("type", eventType))
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	if err := h.outbox.Add(ctx, eventType, subject, data); err != nil {
+		log.Error("failed to store event", logger.Err(err))
+	}
+}
+
+// createdClubPageSize bounds the pending clubs checked for a club that was just created.
+const createdClubPageSize = 50
+
+// findCreatedClub returns the pending club with exactly the name owned by the user,
+// CreateClub does not return the new club. Returns nil if the club is not in the list.
+func (h *Handler) findCreatedClub(ctx context.Context, name string, ownerID int64) (*clubv1.ClubObject, error) {
+	res, err := h.clbClient.ListNotApprovedClubs(ctx, &clubv1.ListNotApprovedClubsRequest{
+		Query:      name,
+		PageNumber: 1,
+		PageSize:   createdClubPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var created *clubv1.ClubObject
+	for _, item := range res.GetList() {
+		if item.GetOwner().GetUserId() != ownerID || item.GetClubs().GetName() != name {
+			continue
+		}
+		if created == nil || item.GetClubs().GetClubId() > created.GetClubId() {
+			created = item.GetClubs()
+		}
+	}
+	return created, nil
+}
+
+func clubDecisionEvent(approved bool) string {
+	if approved {
+		return broker.TypeClubApproved
+	}
+	return broker.TypeClubRejected
+}
+
+func membershipDecisionEvent(approved bool) string {
+	if approved {
+		return broker.TypeMembershipApproved
+	}
+	return broker.TypeMembershipRejected
+}
@@ -0,0 +1,215 @@
+package club
+
+import (
+	"context"
+	"errors"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+)
+
+// Related resources that can be inlined with "expand=".
+const (
+	expandOwner   = "owner"
+	expandRoles   = "roles"
+	expandMembers = "members"
+)
+
+const (
+	// expandMembersLimit is the number of members inlined by expand=members,
+	// the full list is paged through GET /clubs/:id/members.
+	expandMembersLimit = 20
+	// expandConcurrency bounds the parallel calls made to expand a page of clubs.
+	expandConcurrency = 8
+)
+
+var (
+	clubFields         = utils.JSONFields(domain.Club{})
+	clubExpansions     = []string{expandOwner, expandRoles, expandMembers}
+	clubListExpansions = []string{expandOwner, expandRoles}
+)
+
+// clubDetails builds the response of a single club. The owner is not carried by the
+// club message, GetClubOwner is only called when the owner is expanded or owner_id is
+// selected explicitly. The roles come with the club message and are only refreshed
+// with ListClubRoles when they are expanded. A nil fields selects every field.
+func (h *Handler) clubDetails(ctx context.Context, clubObj *clubv1.ClubObject, fields utils.Fieldset, expand utils.Expansions) (map[string]any, error) {
+	c := domain.ClubObjectToClub(clubObj)
+
+	var owner *club.MemberObject
+	if expand.Has(expandOwner) || fields != nil && fields.Has("owner_id") {
+		res, err := h.clbClient.GetClubOwner(ctx, &club.GetClubOwnerRequest{ClubID: c.ID})
+		switch {
+		case err == nil:
+			owner = res
+			c.OwnerID = &owner.UserID
+		case status.Code(err) != codes.NotFound:
+			return nil, err
+		}
+	}
+
+	if expand.Has(expandRoles) {
+		roles, err := h.clbClient.ListClubRoles(ctx, &club.ListClubRolesRequest{ClubID: c.ID})
+		if err != nil {
+			return nil, err
+		}
+		c.Roles = domain.MapRoleObjArrToRoleArr(roles.GetRoles())
+	}
+
+	res, err := utils.SelectFields(c, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// expanded resources are included whatever the fields
+	if expand.Has(expandRoles) {
+		res["roles"] = c.Roles
+	}
+
+	if expand.Has(expandOwner) {
+		res["owner"] = nil
+		if owner != nil {
+			usr, err := h.usrClient.GetUser(ctx, &userv1.GetUserRequest{UserId: owner.UserID})
+			if err != nil {
+				return nil, err
+			}
+			res["owner"] = domain.UserObjectToDomain(usr)
+		}
+	}
+
+	if expand.Has(expandMembers) {
+		members, err := h.clbClient.ListClubMembers(ctx, &clubv1.ListClubMembersRequest{
+			ClubId:     c.ID,
+			PageNumber: 1,
+			PageSize:   expandMembersLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		res["members"] = domain.MapUserObjArrToMemberArr(members.GetUsers())
+	}
+
+	return res, nil
+}
+
+// clubList builds the response of a page of clubs. Search summaries carry the owner
+// id but not the roles, expansions are fetched in parallel per club and per owner.
+// Clubs from ListClubs carry neither, their owner is only looked up when the owner
+// is expanded or owner_id is selected explicitly.
+func (h *Handler) clubList(ctx context.Context, clubs []*domain.Club, fields utils.Fieldset, expand utils.Expansions) ([]map[string]any, error) {
+	var (
+		mu       sync.Mutex
+		owners   = make(map[int64]*domain.User)
+		ownerIDs []int64
+		errs     = make([]error, len(clubs))
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, expandConcurrency)
+	)
+
+	if expand.Has(expandOwner) || fields != nil && fields.Has("owner_id") {
+		for i, c := range clubs {
+			if c.OwnerID != nil {
+				continue
+			}
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, c *domain.Club) {
+				defer func() { <-sem; wg.Done() }()
+
+				owner, err := h.clbClient.GetClubOwner(ctx, &club.GetClubOwnerRequest{ClubID: c.ID})
+				switch {
+				case err == nil:
+					c.OwnerID = &owner.UserID
+				case status.Code(err) != codes.NotFound:
+					errs[i] = err
+				}
+			}(i, c)
+		}
+		wg.Wait()
+
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+	}
+
+	if expand.Has(expandOwner) {
+		for _, c := range clubs {
+			if c.OwnerID == nil {
+				continue
+			}
+			if _, ok := owners[*c.OwnerID]; !ok {
+				owners[*c.OwnerID] = nil
+				ownerIDs = append(ownerIDs, *c.OwnerID)
+			}
+		}
+	}
+
+	if expand.Has(expandRoles) {
+		for i, c := range clubs {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int, c *domain.Club) {
+				defer func() { <-sem; wg.Done() }()
+
+				roles, err := h.clbClient.ListClubRoles(ctx, &club.ListClubRolesRequest{ClubID: c.ID})
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				c.Roles = domain.MapRoleObjArrToRoleArr(roles.GetRoles())
+			}(i, c)
+		}
+	}
+
+	var ownerErrs []error
+	for _, ownerID := range ownerIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ownerID int64) {
+			defer func() { <-sem; wg.Done() }()
+
+			usr, err := h.usrClient.GetUser(ctx, &userv1.GetUserRequest{UserId: ownerID})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				ownerErrs = append(ownerErrs, err)
+				return
+			}
+			user := domain.UserObjectToDomain(usr)
+			owners[ownerID] = &user
+		}(ownerID)
+	}
+	wg.Wait()
+
+	if err := errors.Join(append(errs, ownerErrs...)...); err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string]any, len(clubs))
+	for i, c := range clubs {
+		item, err := utils.SelectFields(c, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		if expand.Has(expandRoles) {
+			item["roles"] = c.Roles
+		}
+		if expand.Has(expandOwner) {
+			item["owner"] = nil
+			if c.OwnerID != nil {
+				item["owner"] = owners[*c.OwnerID]
+			}
+		}
+
+		res[i] = item
+	}
+
+	return res, nil
+}
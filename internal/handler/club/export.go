@@ -0,0 +1,246 @@
+package club
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/xlsx"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// exportPageSize is the ListClubMembers page size used while streaming an export.
+	exportPageSize = 100
+	// exportConcurrency bounds the parallel GetUser calls made for one page of members.
+	exportConcurrency = 8
+)
+
+var exportHeader = []string{"name", "email", "barcode", "major", "group", "year", "roles"}
+
+// exportStatusTrailer is the HTTP trailer reporting whether an export is complete,
+// the status code is already sent when a later page fails.
+const exportStatusTrailer = "X-Export-Status"
+
+// exportIncompleteRow ends an export that failed after the first page, so a cut short
+// file cannot be mistaken for the full member list.
+var exportIncompleteRow = []string{"ERROR: the export is incomplete, not all members could be loaded, try again"}
+
+// rowWriter is the file format of a member export.
+type rowWriter interface {
+	WriteRow(cells []string) error
+	Flush() error
+	Close() error
+}
+
+// ExportMembersHandler streams the members of the club as a CSV or XLSX file. Members
+// are paged from the club service and written as they arrive, so the size of the club
+// does not matter. Errors after the first page can no longer change the status code,
+// the file then ends with exportIncompleteRow and the X-Export-Status trailer is
+// "incomplete" instead of "complete".
+func (h *Handler) ExportMembersHandler(c *gin.Context) {
+	const op = "ClubHandler.ExportMembersHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if format != "csv" && format != "xlsx" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "format must be csv or xlsx"})
+		return
+	}
+
+	// the first page is fetched before anything is written, so a failing club service
+	// still gets a proper error response
+	page, err := h.clbClient.ListClubMembers(c, &clubv1.ListClubMembersRequest{
+		ClubId:     access.ClubID,
+		PageNumber: 1,
+		PageSize:   exportPageSize,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	filename := fmt.Sprintf("club-%d-members.%s", access.ClubID, format)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Trailer", exportStatusTrailer)
+
+	var w rowWriter
+	switch format {
+	case "xlsx":
+		c.Header("Content-Type", xlsx.ContentType)
+		w, err = xlsx.NewWriter(c.Writer, "Members")
+	default:
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		w = newCSVWriter(c.Writer)
+	}
+	if err != nil {
+		log.Error("failed to start export", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Status(http.StatusOK)
+
+	if err := h.writeMembers(c, w, access.ClubID, page); err != nil {
+		log.Error("member export cut short", slog.Int64("club_id", access.ClubID), logger.Err(err))
+		c.Writer.Header().Set(exportStatusTrailer, "incomplete")
+		// the client may be gone already, then the marker cannot be written either
+		if err := w.WriteRow(exportIncompleteRow); err == nil {
+			w.Close()
+		}
+		return
+	}
+	if err := w.Close(); err != nil {
+		log.Error("failed to finish export", slog.Int64("club_id", access.ClubID), logger.Err(err))
+		c.Writer.Header().Set(exportStatusTrailer, "incomplete")
+		return
+	}
+	c.Writer.Header().Set(exportStatusTrailer, "complete")
+
+	log.Info("members exported", slog.Int64("club_id", access.ClubID), slog.Int64("user_id", access.UserID), slog.String("format", format))
+}
+
+// writeMembers writes the header and every page of members starting with first,
+// the caller closes w.
+func (h *Handler) writeMembers(c *gin.Context, w rowWriter, clubID int64, first *clubv1.ListClubMembersResponse) error {
+	if err := w.WriteRow(exportHeader); err != nil {
+		return err
+	}
+
+	page := first
+	for pageNumber := int32(1); ; pageNumber++ {
+		rows, err := h.exportRows(c, page.GetUsers())
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := w.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+
+		last := page.GetMetadata().GetLastPage()
+		if len(page.GetUsers()) == 0 || (last > 0 && pageNumber >= last) || (last == 0 && len(page.GetUsers()) < exportPageSize) {
+			break
+		}
+
+		page, err = h.clbClient.ListClubMembers(c, &clubv1.ListClubMembersRequest{
+			ClubId:     clubID,
+			PageNumber: pageNumber + 1,
+			PageSize:   exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportRows builds the rows of a page of members. Major, group and year are only
+// known to the user service, they are fetched in parallel and left empty for users
+// that no longer exist.
+func (h *Handler) exportRows(ctx context.Context, members []*clubv1.UserObject) ([][]string, error) {
+	rows := make([][]string, len(members))
+	errs := make([]error, len(members))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, exportConcurrency)
+	for i, member := range members {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, member *clubv1.UserObject) {
+			defer func() { <-sem; wg.Done() }()
+
+			usr, err := h.usrClient.GetUser(ctx, &userv1.GetUserRequest{UserId: member.GetUserId()})
+			if err != nil && status.Code(err) != codes.NotFound {
+				errs[i] = err
+				return
+			}
+
+			roles := make([]string, len(member.GetRole()))
+			for j, role := range member.GetRole() {
+				roles[j] = role.GetName()
+			}
+
+			var year string
+			if usr.GetYear() != 0 {
+				year = strconv.Itoa(int(usr.GetYear()))
+			}
+
+			rows[i] = []string{
+				strings.TrimSpace(member.GetFirstName() + " " + member.GetLastName()),
+				member.GetEmail(),
+				member.GetBarcode(),
+				usr.GetMajor(),
+				usr.GetGroupName(),
+				year,
+				strings.Join(roles, ", "),
+			}
+		}(i, member)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// csvWriter escapes cells that spreadsheet applications would evaluate as formulas.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) WriteRow(cells []string) error {
+	safe := make([]string, len(cells))
+	for i, cell := range cells {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		safe[i] = cell
+	}
+	return w.w.Write(safe)
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) Close() error {
+	return w.Flush()
+}
@@ -2,34 +2,58 @@ package club
 
 import (
 	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/attendance"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/notification"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/realtime"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/webhook"
 	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log/slog"
 	"net/http"
-	"strings"
+	"strconv"
 )
 
 type Handler struct {
-	clbClient *club.Client
-	log       *slog.Logger
+	clbClient  *club.Client
+	usrClient  *user.Client
+	ntfClient  *notification.Client
+	hub        *realtime.Hub
+	webhooks   *webhook.Service
+	outbox     *broker.Outbox
+	attendance *attendance.Service
+	log        *slog.Logger
 }
 
-// New creates and returns a new User Handler instance
+// New creates and returns a new Club Handler instance
 // Parameters:
-//   - client: A *user.Client which is a gRPC client for the user service.
+//   - client: A *club.Client which is a gRPC client for the club service.
+//   - usrClient: A *user.Client which is a gRPC client for the user service.
+//   - ntfClient: A *notification.Client used to notify users about club decisions.
+//   - hub: A *realtime.Hub streaming club events to connected clients.
+//   - webhooks: A *webhook.Service delivering club events to registered webhook endpoints.
+//   - outbox: A *broker.Outbox publishing domain events to the message broker.
+//   - attendance: A *attendance.Service tracking meeting check-ins.
 //   - log: A *slog.Logger used for logging messages and errors.
 //
 // Returns:
-//   - A Handler struct that encapsulates the provided user service client and logger.
-func New(client *club.Client, log *slog.Logger) Handler {
+//   - A Handler struct that encapsulates the provided service clients and logger.
+func New(client *club.Client, usrClient *user.Client, ntfClient *notification.Client, hub *realtime.Hub, webhooks *webhook.Service, outbox *broker.Outbox, attendance *attendance.Service, log *slog.Logger) Handler {
 	return Handler{
-		clbClient: client,
-		log:       log,
+		clbClient:  client,
+		usrClient:  usrClient,
+		ntfClient:  ntfClient,
+		hub:        hub,
+		webhooks:   webhooks,
+		outbox:     outbox,
+		attendance: attendance,
+		log:        log,
 	}
 }
 
@@ -80,6 +104,27 @@ func (h *Handler) CreateClubHandler(c *gin.Context) {
 		return
 	}
 
+	created := map[string]any{
+		"name":        input.Name,
+		"description": input.Description,
+		"club_type":   input.ClubType,
+		"owner_id":    userID,
+	}
+	// CreateClub does not return the new club, when it cannot be resolved the event is
+	// still emitted, just without club_id and subject
+	var subject string
+	switch createdClub, err := h.findCreatedClub(c, input.Name, userID); {
+	case err != nil:
+		log.Warn("failed to resolve created club, club.created is emitted without club_id", logger.Err(err))
+	case createdClub == nil:
+		log.Warn("created club not found, club.created is emitted without club_id", slog.String("name", input.Name))
+	default:
+		created["club_id"] = createdClub.GetClubId()
+		subject = strconv.FormatInt(createdClub.GetClubId(), 10)
+	}
+	h.emit(broker.TypeClubCreated, subject, created)
+	h.dispatchWebhook(webhook.EventClubCreated, created)
+
 	c.Status(http.StatusCreated)
 
 }
@@ -95,6 +140,20 @@ func (h *Handler) GetClubHandler(c *gin.Context) {
 		return
 	}
 
+	fields, err := utils.GetFieldsFromQuery(c, clubFields)
+	if err != nil {
+		log.Warn("invalid fields", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expand, err := utils.GetExpandFromQuery(c, clubExpansions)
+	if err != nil {
+		log.Warn("invalid expand", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	res, err := h.clbClient.GetClub(c, &clubv1.GetClubRequest{ClubId: clubID})
 	if err != nil {
 		switch {
@@ -111,21 +170,20 @@ func (h *Handler) GetClubHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"club": domain.ClubObjectToClub(res)})
+	details, err := h.clubDetails(c, res, fields, expand)
+	if err != nil {
+		log.Error("failed to get club details", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"club": details})
 }
 
 func (h *Handler) ListClubsHandler(c *gin.Context) {
 	const op = "ClubHandler.ListClubsHandler"
 	log := h.log.With(slog.String("op", op))
 
-	query := c.Query("query")
-	//todo: make in another way
-	clubTypeStr := c.Query("club_types")
-	var clubType []string
-	if clubTypeStr != "" {
-		clubType = strings.Split(clubTypeStr, ",")
-	}
-
 	page, err := utils.GetIntFromQuery(c, "page")
 	if err != nil {
 		log.Warn("failed to get page query parameter", logger.Err(err))
@@ -140,25 +198,73 @@ func (h *Handler) ListClubsHandler(c *gin.Context) {
 		return
 	}
 
-	res, err := h.clbClient.ListClubs(c, &clubv1.ListClubRequest{
-		Query:      query,
-		ClubType:   clubType,
-		PageNumber: int32(page),
-		PageSize:   int32(pageSize),
-	})
+	fields, err := utils.GetFieldsFromQuery(c, clubFields)
 	if err != nil {
-		switch {
-		case status.Code(err) == codes.InvalidArgument:
-			log.Warn("invalid arguments", logger.Err(err))
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
-		default:
-			log.Error("internal", logger.Err(err))
-			c.AbortWithStatus(http.StatusInternalServerError)
+		log.Warn("invalid fields", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expand, err := utils.GetExpandFromQuery(c, clubListExpansions)
+	if err != nil {
+		log.Warn("invalid expand", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req, err := searchClubsRequest(c)
+	if err != nil {
+		log.Warn("invalid search query parameters", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.PageNumber = int32(page)
+	req.PageSize = int32(pageSize)
+
+	var (
+		list     []*domain.Club
+		metadata domain.Pagination
+	)
+	if needsSearch(req) {
+		res, err := h.clbClient.SearchClubs(c, req)
+		if err != nil {
+			abortListClubs(c, log, err)
+			return
+		}
+		list, metadata = domain.MapClubSummaryObjArrToClubArr(res.GetClubs()), domain.SearchPaginationToDomain(res.GetMetadata())
+	} else {
+		res, err := h.clbClient.ListClubs(c, &clubv1.ListClubRequest{
+			Query:      req.Query,
+			ClubType:   req.ClubTypes,
+			PageNumber: req.PageNumber,
+			PageSize:   req.PageSize,
+		})
+		if err != nil {
+			abortListClubs(c, log, err)
+			return
 		}
+		list, metadata = domain.MapClubObjArrToClubArr(res.GetClubs()), domain.ClubPaginationToDomain(res.GetMetadata())
+	}
+
+	clubs, err := h.clubList(c, list, fields, expand)
+	if err != nil {
+		log.Error("failed to expand clubs", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"clubs": res.Clubs, "metadata": res.Metadata})
+	c.JSON(http.StatusOK, gin.H{"clubs": clubs, "metadata": metadata})
+}
+
+func abortListClubs(c *gin.Context, log *slog.Logger, err error) {
+	switch {
+	case status.Code(err) == codes.InvalidArgument:
+		log.Warn("invalid arguments", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+	default:
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}
 }
 
 func (h *Handler) ListClubMembersHandler(c *gin.Context) {
@@ -205,7 +311,7 @@ func (h *Handler) ListClubMembersHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"members": domain.MapUserObjArrToMemberArr(res.GetUsers()), "metadata": res.GetMetadata()})
+	c.JSON(http.StatusOK, gin.H{"members": domain.MapUserObjArrToMemberArr(res.GetUsers()), "metadata": domain.ClubPaginationToDomain(res.GetMetadata())})
 
 }
 
@@ -234,6 +340,12 @@ func (h *Handler) NewClubHandler(c *gin.Context) {
 		action = clubv1.HandleClubAction_APPROVE
 	}
 
+	// the owner is only needed to notify them, a failed lookup must not block the decision
+	ownerID, err := h.pendingClubOwner(c, clubID)
+	if err != nil {
+		log.Warn("failed to resolve club owner, the owner is not notified", logger.Err(err))
+	}
+
 	_, err = h.clbClient.HandleNewClub(c, &clubv1.HandleNewClubRequest{
 		ClubId: clubID,
 		Action: action,
@@ -253,6 +365,10 @@ func (h *Handler) NewClubHandler(c *gin.Context) {
 		return
 	}
 
+	h.notifyClubDecided(clubID, ownerID, action == clubv1.HandleClubAction_APPROVE)
+	h.dispatchWebhook(clubDecisionWebhook(action == clubv1.HandleClubAction_APPROVE), map[string]any{"club_id": clubID})
+	h.emit(clubDecisionEvent(action == clubv1.HandleClubAction_APPROVE), strconv.FormatInt(clubID, 10), map[string]any{"club_id": clubID})
+
 	c.Status(http.StatusCreated)
 
 }
@@ -312,6 +428,15 @@ func (h *Handler) HandleJoinRequestHandler(c *gin.Context) {
 		return
 	}
 
+	h.notifyJoinRequestDecided(clubID, input.TargetID, action == clubv1.HandleClubAction_APPROVE)
+	decision := map[string]any{
+		"club_id":    clubID,
+		"user_id":    input.TargetID,
+		"decided_by": userID,
+	}
+	h.dispatchWebhook(membershipDecisionWebhook(action == clubv1.HandleClubAction_APPROVE), decision)
+	h.emit(membershipDecisionEvent(action == clubv1.HandleClubAction_APPROVE), strconv.FormatInt(clubID, 10), decision)
+
 	c.Status(http.StatusCreated)
 }
 
@@ -352,6 +477,8 @@ func (h *Handler) JoinRequestHandler(c *gin.Context) {
 		return
 	}
 
+	h.notifyJoinRequestCreated(clubID, userID)
+
 	c.Status(http.StatusCreated)
 
 }
@@ -400,7 +527,7 @@ func (h *Handler) ListJoinRequestsHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"users": domain.MapUserObjArrToMemberArr(res.GetUsers()), "metadata": res.GetMetadata()})
+	c.JSON(http.StatusOK, gin.H{"users": domain.MapUserObjArrToMemberArr(res.GetUsers()), "metadata": domain.ClubPaginationToDomain(res.GetMetadata())})
 }
 
 func (h *Handler) ListNewClubRequestsHandler(c *gin.Context) {
@@ -408,12 +535,7 @@ func (h *Handler) ListNewClubRequestsHandler(c *gin.Context) {
 	log := h.log.With(slog.String("op", op))
 
 	query := c.Query("query")
-	//todo: make in another way
-	clubTypeStr := c.Query("club_types")
-	var clubType []string
-	if clubTypeStr != "" {
-		clubType = strings.Split(clubTypeStr, ",")
-	}
+	clubTypes := utils.GetStringsFromQuery(c, "club_types")
 
 	page, err := utils.GetIntFromQuery(c, "page")
 	if err != nil {
@@ -431,7 +553,7 @@ func (h *Handler) ListNewClubRequestsHandler(c *gin.Context) {
 
 	res, err := h.clbClient.ListNotApprovedClubs(c, &clubv1.ListNotApprovedClubsRequest{
 		Query:      query,
-		ClubType:   clubType,
+		ClubType:   clubTypes,
 		PageNumber: int32(page),
 		PageSize:   int32(pageSize),
 	})
@@ -450,5 +572,5 @@ func (h *Handler) ListNewClubRequestsHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"items": res.GetList(), "metadata": res.Metadata})
+	c.JSON(http.StatusOK, gin.H{"items": domain.MapNotActivatedClubArrToClubRequestArr(res.GetList()), "metadata": domain.ClubPaginationToDomain(res.GetMetadata())})
 }
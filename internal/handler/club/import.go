@@ -0,0 +1,319 @@
+package club
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/webhook"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	maxImportSize = 1 << 20
+	maxImportRows = 1000
+	// importConcurrency bounds the rows resolved and added in parallel.
+	importConcurrency = 8
+)
+
+// Import modes.
+const (
+	importModeInvite = "invite"
+	importModeAdd    = "add"
+)
+
+// Import row statuses. In a dry run added and invited report what would happen.
+// Rows with the error status could not be processed, unless the error says otherwise they
+// failed on another service and can be retried as they are.
+const (
+	importAdded          = "added"
+	importInvited        = "invited"
+	importAlreadyMember  = "already_member"
+	importAlreadyInvited = "already_invited"
+	importNotFound       = "not_found"
+	importInvalid        = "invalid"
+	importError          = "error"
+)
+
+var (
+	barcodeRegexp = regexp.MustCompile(`^[0-9A-Za-z]{3,32}$`)
+
+	errImportEmpty   = errors.New("the file has no rows")
+	errImportTooMany = errors.New("the file has too many rows")
+)
+
+type importRow struct {
+	Row    int    `json:"row"`
+	Value  string `json:"value"`
+	Status string `json:"status"`
+	UserID int64  `json:"user_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportMembersHandler adds existing users to the club from a CSV with a barcode or an
+// email in the first column of every row, a header row is skipped. The file is sent as
+// the "file" form field or as a text/csv body.
+//
+// Query parameters:
+//   - mode: invite (default) sends invitations, add makes the users members right away
+//   - dry_run: true resolves and checks every row without changing anything
+//
+// The response reports every row, rows are processed independently so one bad row
+// does not stop the others.
+func (h *Handler) ImportMembersHandler(c *gin.Context) {
+	const op = "ClubHandler.ImportMembersHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	mode := c.DefaultQuery("mode", importModeInvite)
+	if mode != importModeInvite && mode != importModeAdd {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "mode must be invite or add"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	data, err := readImportFile(c)
+	if err != nil {
+		log.Warn("failed to read import file", logger.Err(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "the file must not exceed 1MB"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "a csv file must be sent as the file form field or as a text/csv body"})
+		return
+	}
+
+	rows, err := parseImportRows(data)
+	if err != nil {
+		log.Warn("invalid import file", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, importConcurrency)
+	for i := range rows {
+		if rows[i].Status != "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(row *importRow) {
+			defer func() { <-sem; wg.Done() }()
+			h.importRow(c, log, access, row, mode, dryRun)
+		}(&rows[i])
+	}
+	wg.Wait()
+
+	summary := make(map[string]int)
+	for _, row := range rows {
+		summary[row.Status]++
+	}
+
+	log.Info("members imported",
+		slog.Int64("club_id", access.ClubID),
+		slog.Int64("user_id", access.UserID),
+		slog.String("mode", mode),
+		slog.Bool("dry_run", dryRun),
+		slog.Int("rows", len(rows)),
+	)
+
+	c.JSON(http.StatusOK, gin.H{"mode": mode, "dry_run": dryRun, "summary": summary, "rows": rows})
+}
+
+// importRow resolves the user of the row and adds or invites them, the outcome is
+// recorded on the row.
+func (h *Handler) importRow(ctx context.Context, log *slog.Logger, access *domain.ClubAccess, row *importRow, mode string, dryRun bool) {
+	userID, err := h.resolveImportUser(ctx, row.Value)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			row.Status = importNotFound
+			return
+		case codes.ResourceExhausted:
+			row.Status, row.Error = importError, "too many users match the value, the user could not be looked up"
+			return
+		}
+		log.Error("failed to resolve user", slog.Int("row", row.Row), logger.Err(err))
+		row.Status, row.Error = importError, "failed to look up the user, try again"
+		return
+	}
+	row.UserID = userID
+
+	_, err = h.clbClient.GetClubMember(ctx, &club.GetClubMemberRequest{ClubID: access.ClubID, UserID: userID})
+	switch {
+	case err == nil:
+		row.Status = importAlreadyMember
+		return
+	case status.Code(err) != codes.NotFound:
+		log.Error("failed to get club member", slog.Int("row", row.Row), logger.Err(err))
+		row.Status, row.Error = importError, "failed to check the membership, try again"
+		return
+	}
+
+	outcome := importInvited
+	if mode == importModeAdd {
+		outcome = importAdded
+	}
+	if dryRun {
+		row.Status = outcome
+		return
+	}
+
+	req := &club.AddMemberRequest{ClubID: access.ClubID, UserID: userID, ActorID: access.UserID}
+	if mode == importModeAdd {
+		_, err = h.clbClient.AddMember(ctx, req)
+	} else {
+		_, err = h.clbClient.InviteMember(ctx, req)
+	}
+	switch {
+	case err == nil:
+		row.Status = outcome
+		if mode == importModeAdd {
+			added := map[string]any{
+				"club_id":  access.ClubID,
+				"user_id":  userID,
+				"added_by": access.UserID,
+			}
+			h.dispatchWebhook(webhook.EventMembershipAdded, added)
+			h.emit(broker.TypeMembershipAdded, strconv.FormatInt(access.ClubID, 10), added)
+		}
+	// membership was checked above, so an existing invitation is the likely conflict
+	// of an invite, a concurrent join the one of an add
+	case isAlreadyExists(err) && mode == importModeInvite:
+		row.Status = importAlreadyInvited
+	case isAlreadyExists(err):
+		row.Status = importAlreadyMember
+	default:
+		log.Error("failed to import member", slog.Int("row", row.Row), logger.Err(err))
+		row.Status, row.Error = importError, "failed to add the user, try again"
+	}
+}
+
+// resolveImportUser finds the user with exactly the email or barcode.
+// Returns a codes.NotFound error if there is no such user and a codes.ResourceExhausted
+// error if the lookup gave up before finding them.
+func (h *Handler) resolveImportUser(ctx context.Context, value string) (int64, error) {
+	find := h.usrClient.FindUserByBarcode
+	if strings.Contains(value, "@") {
+		find = h.usrClient.FindUserByEmail
+	}
+
+	usr, err := find(ctx, value)
+	if err != nil {
+		return 0, err
+	}
+	return usr.GetUserId(), nil
+}
+
+func readImportFile(c *gin.Context) ([]byte, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize+(1<<10))
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		if fh.Size > maxImportSize {
+			return nil, &http.MaxBytesError{Limit: maxImportSize}
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return io.ReadAll(f)
+	}
+
+	return io.ReadAll(c.Request.Body)
+}
+
+// parseImportRows reads the first column of every row. Invalid values are reported
+// right away, duplicates of an earlier row as invalid as well.
+func parseImportRows(data []byte) ([]importRow, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var rows []importRow
+	seen := make(map[string]int)
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// the row is the line the record starts on, quoted fields can span lines
+		line, _ := r.FieldPos(0)
+
+		value := strings.TrimSpace(record[0])
+		if value == "" {
+			continue
+		}
+		if first && isImportHeader(value) {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, errImportTooMany
+		}
+
+		row := importRow{Row: line, Value: value}
+		if strings.Contains(value, "@") {
+			addr, err := mail.ParseAddress(value)
+			if err != nil || addr.Address != value {
+				row.Status, row.Error = importInvalid, "not a valid email"
+			}
+			row.Value = strings.ToLower(value)
+		} else if !barcodeRegexp.MatchString(value) {
+			row.Status, row.Error = importInvalid, "not a valid barcode or email"
+		}
+
+		if first, ok := seen[row.Value]; ok && row.Status == "" {
+			row.Status, row.Error = importInvalid, "duplicate of row "+strconv.Itoa(first)
+		}
+		seen[row.Value] = line
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, errImportEmpty
+	}
+	return rows, nil
+}
+
+func isImportHeader(value string) bool {
+	switch strings.ToLower(value) {
+	case "barcode", "email", "user":
+		return true
+	}
+	return false
+}
+
+func isAlreadyExists(err error) bool {
+	return status.Code(err) == codes.AlreadyExists
+}
@@ -0,0 +1,271 @@
+package club
+
+import (
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) LeaveClubHandler(c *gin.Context) {
+	const op = "ClubHandler.LeaveClubHandler"
+	log := h.log.With(slog.String("op", op))
+
+	clubID, err := utils.GetIntFromParams(c.Params, "id")
+	if err != nil {
+		log.Warn("failed to get id params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	userID := userIDFromCtx.(int64)
+
+	member, err := h.clbClient.GetClubMember(c, &club.GetClubMemberRequest{ClubID: clubID, UserID: userID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("not a member", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "you are not a member of the club"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if member.IsOwner {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "the owner cannot leave the club, transfer the ownership first"})
+		return
+	}
+
+	_, err = h.clbClient.LeaveClub(c, &clubv1.LeaveClubRequest{ClubId: clubID, UserId: userID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Warn("cannot leave club", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.emit(broker.TypeMembershipLeft, strconv.FormatInt(clubID, 10), map[string]any{"club_id": clubID, "user_id": userID})
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) RemoveMemberHandler(c *gin.Context) {
+	const op = "ClubHandler.RemoveMemberHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	targetID, err := utils.GetIntFromParams(c.Params, "userID")
+	if err != nil {
+		log.Warn("failed to get userID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if targetID == access.UserID {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "use DELETE /clubs/:id/members/me to leave the club"})
+		return
+	}
+
+	var input struct {
+		Reason string `json:"reason" binding:"max=500"`
+	}
+	// the body is optional
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			log.Error("decoding err", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	target, err := h.clbClient.GetClubMember(c, &club.GetClubMemberRequest{ClubID: access.ClubID, UserID: targetID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("member not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "user is not a member of the club"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if target.IsOwner {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "the owner cannot be removed from the club"})
+		return
+	}
+
+	if !outranks(access, target) {
+		log.Warn("member outranks caller", slog.Int64("target_id", targetID))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you cannot remove a member ranked at or above you"})
+		return
+	}
+
+	_, err = h.clbClient.RemoveMember(c, &club.RemoveMemberRequest{
+		ClubID:  access.ClubID,
+		UserID:  targetID,
+		ActorID: access.UserID,
+		Reason:  input.Reason,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("member not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.emit(broker.TypeMembershipRemoved, strconv.FormatInt(access.ClubID, 10), map[string]any{
+		"club_id":    access.ClubID,
+		"user_id":    targetID,
+		"removed_by": access.UserID,
+	})
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) TransferOwnershipHandler(c *gin.Context) {
+	const op = "ClubHandler.TransferOwnershipHandler"
+	log := h.log.With(slog.String("op", op))
+
+	clubID, err := utils.GetIntFromParams(c.Params, "id")
+	if err != nil {
+		log.Warn("failed to get id params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	userID := userIDFromCtx.(int64)
+
+	var input struct {
+		NewOwnerID int64  `json:"new_owner_id" binding:"required"`
+		Password   string `json:"password" binding:"required"`
+	}
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.NewOwnerID == userID {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "you already own the club"})
+		return
+	}
+
+	access, err := h.clubAccess(c, clubID, userID)
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	if !access.IsOwner {
+		log.Warn("not club owner")
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "only the owner can transfer the club"})
+		return
+	}
+
+	_, err = h.usrClient.VerifyPassword(c, &user.VerifyPasswordRequest{UserID: userID, Password: input.Password})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.PermissionDenied:
+			log.Warn("wrong password", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "wrong password"})
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	_, err = h.clbClient.GetClubMember(c, &club.GetClubMemberRequest{ClubID: clubID, UserID: input.NewOwnerID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("new owner is not a member", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "the new owner must be a member of the club"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	_, err = h.clbClient.TransferOwnership(c, &club.TransferOwnershipRequest{
+		ClubID:     clubID,
+		OwnerID:    userID,
+		NewOwnerID: input.NewOwnerID,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Warn("cannot transfer ownership", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,168 @@
+package club
+
+import (
+	"context"
+	"fmt"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/notification"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/realtime"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// notifyTimeout bounds the background work of a single notification.
+const notifyTimeout = 10 * time.Second
+
+// notifyJoinRequestCreated tells the members managing the club about a new join request.
+func (h *Handler) notifyJoinRequestCreated(clubID, userID int64) {
+	h.publish(realtime.ClubTopic(clubID), realtime.TypeJoinRequestCreated, map[string]any{
+		"club_id": clubID,
+		"user_id": userID,
+	})
+}
+
+// notifyJoinRequestDecided tells the user that their join request was approved or rejected.
+func (h *Handler) notifyJoinRequestDecided(clubID, userID int64, approved bool) {
+	data := map[string]any{"club_id": clubID, "user_id": userID, "status": decisionStatus(approved)}
+	h.publish(realtime.UserTopic(userID), realtime.TypeJoinRequestDecided, data)
+	h.publish(realtime.ClubTopic(clubID), realtime.TypeJoinRequestDecided, data)
+
+	h.notify("join request decided", func(ctx context.Context) (*notification.SendNotificationRequest, error) {
+		name, err := h.clubName(ctx, clubID)
+		if err != nil {
+			return nil, err
+		}
+
+		req := &notification.SendNotificationRequest{
+			UserID: userID,
+			Type:   notification.TypeJoinRequestRejected,
+			Title:  "Join request rejected",
+			Body:   fmt.Sprintf("Your request to join %s was rejected.", name),
+			Data:   map[string]string{"club_id": strconv.FormatInt(clubID, 10)},
+		}
+		if approved {
+			req.Type = notification.TypeJoinRequestApproved
+			req.Title = "Join request approved"
+			req.Body = fmt.Sprintf("Welcome to %s! Your request to join was approved.", name)
+		}
+		return req, nil
+	})
+}
+
+// notifyClubDecided tells the club owner that the club was approved or rejected.
+// Only approvals are stored in the inbox, both are sent over the realtime channel.
+// The owner is resolved by the caller before the decision, a rejected club may be gone
+// afterwards. A zero ownerID sends nothing.
+func (h *Handler) notifyClubDecided(clubID, ownerID int64, approved bool) {
+	if ownerID == 0 {
+		return
+	}
+
+	h.publish(realtime.UserTopic(ownerID), realtime.TypeClubDecided, map[string]any{
+		"club_id": clubID,
+		"status":  decisionStatus(approved),
+	})
+	if !approved {
+		return
+	}
+
+	h.notify("club decided", func(ctx context.Context) (*notification.SendNotificationRequest, error) {
+		name, err := h.clubName(ctx, clubID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &notification.SendNotificationRequest{
+			UserID: ownerID,
+			Type:   notification.TypeClubApproved,
+			Title:  "Club approved",
+			Body:   fmt.Sprintf("%s was approved and is now visible to everyone.", name),
+			Data:   map[string]string{"club_id": strconv.FormatInt(clubID, 10)},
+		}, nil
+	})
+}
+
+// notify builds and sends a notification in the background, build may return a nil
+// request to send nothing. It never fails the request that triggered it, errors are only logged.
+func (h *Handler) notify(event string, build func(ctx context.Context) (*notification.SendNotificationRequest, error)) {
+	const op = "ClubHandler.notify"
+	log := h.log.With(slog.String("op", op), slog.String("event", event))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		req, err := build(ctx)
+		if err != nil {
+			log.Error("failed to build notification", logger.Err(err))
+			return
+		}
+		if req == nil || h.ntfClient == nil {
+			return
+		}
+
+		res, err := h.ntfClient.SendNotification(ctx, req)
+		if err != nil {
+			log.Error("failed to send notification", slog.Int64("user_id", req.UserID), logger.Err(err))
+			return
+		}
+
+		h.publish(realtime.UserTopic(req.UserID), realtime.TypeNotificationCreated, domain.NotificationObjectToNotification(res))
+	}()
+}
+
+func (h *Handler) publish(topic, eventType string, data any) {
+	if h.hub != nil {
+		h.hub.Publish(topic, eventType, data)
+	}
+}
+
+func (h *Handler) clubName(ctx context.Context, clubID int64) (string, error) {
+	res, err := h.clbClient.GetClub(ctx, &clubv1.GetClubRequest{ClubId: clubID})
+	if err != nil {
+		return "", err
+	}
+	return res.GetName(), nil
+}
+
+func decisionStatus(approved bool) string {
+	if approved {
+		return "approved"
+	}
+	return "rejected"
+}
+
+// pendingClubMaxPages bounds the pages of pending clubs searched for a club.
+const pendingClubMaxPages = 20
+
+// pendingClubOwner finds the owner of a club waiting for approval in the list of
+// pending clubs, which carries the owner. Returns a codes.NotFound error if the club
+// is not pending.
+func (h *Handler) pendingClubOwner(ctx context.Context, clubID int64) (int64, error) {
+	for page := int32(1); page <= pendingClubMaxPages; page++ {
+		res, err := h.clbClient.ListNotApprovedClubs(ctx, &clubv1.ListNotApprovedClubsRequest{
+			PageNumber: page,
+			PageSize:   createdClubPageSize,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		for _, item := range res.GetList() {
+			if item.GetClubs().GetClubId() == clubID {
+				return item.GetOwner().GetUserId(), nil
+			}
+		}
+
+		if len(res.GetList()) < createdClubPageSize || page >= res.GetMetadata().GetLastPage() {
+			break
+		}
+	}
+
+	return 0, status.Error(codes.NotFound, "club is not pending approval")
+}
@@ -0,0 +1,476 @@
+package club
+
+import (
+	"container/heap"
+	"errors"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	maxPostImages     = 4
+	maxPostImageSize  = 5 << 20
+	maxPostTextLength = 5000
+
+	defaultPostsLimit = 20
+	maxPostsLimit     = 50
+
+	// feedConcurrency bounds the parallel ListClubPosts calls made for one feed page.
+	feedConcurrency = 8
+)
+
+var errPostTextTooLong = errors.New("text must be at most " + strconv.Itoa(maxPostTextLength) + " characters")
+
+// postInput is the body of create and edit requests, sent either as JSON or as a
+// multipart form with a "text" field and up to maxPostImages "images" files.
+type postInput struct {
+	Text    *string
+	Images  []club.ImageUpload
+	HasForm bool
+}
+
+func (h *Handler) ListPostsHandler(c *gin.Context) {
+	const op = "ClubHandler.ListPostsHandler"
+	log := h.log.With(slog.String("op", op))
+
+	clubID, err := utils.GetIntFromParams(c.Params, "id")
+	if err != nil {
+		log.Warn("failed to get id params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cursor, limit, err := pageParams(c)
+	if err != nil {
+		log.Warn("invalid page parameters", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.clbClient.ListClubPosts(c, &club.ListClubPostsRequest{
+		ClubID:          clubID,
+		BeforeCreatedAt: cursor.CreatedAt,
+		BeforePostID:    cursor.PostID,
+		Limit:           int32(limit),
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	body := gin.H{
+		"posts":       domain.MapPostObjArrToDomain(res.GetPosts()),
+		"next_cursor": nextCursor(res.GetPosts(), limit),
+	}
+
+	// pinned posts are shown above the timeline, so they only come with the first page
+	if cursor.PostID == 0 {
+		pinned, err := h.clbClient.ListClubPosts(c, &club.ListClubPostsRequest{ClubID: clubID, PinnedOnly: true, Limit: maxPostsLimit})
+		if err != nil {
+			log.Error("failed to list pinned posts", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		body["pinned"] = domain.MapPostObjArrToDomain(pinned.GetPosts())
+	}
+
+	c.JSON(http.StatusOK, body)
+}
+
+func (h *Handler) CreatePostHandler(c *gin.Context) {
+	const op = "ClubHandler.CreatePostHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	input, err := readPostInput(c)
+	if err != nil {
+		abortPostInput(c, log, err)
+		return
+	}
+
+	if (input.Text == nil || strings.TrimSpace(*input.Text) == "") && len(input.Images) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "post must have text or images"})
+		return
+	}
+
+	req := &club.CreatePostRequest{ClubID: access.ClubID, AuthorID: access.UserID, Images: input.Images}
+	if input.Text != nil {
+		req.Text = *input.Text
+	}
+
+	res, err := h.clbClient.CreatePost(c, req)
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"post": domain.PostObjectToPost(res)})
+}
+
+func (h *Handler) UpdatePostHandler(c *gin.Context) {
+	const op = "ClubHandler.UpdatePostHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	postID, err := utils.GetIntFromParams(c.Params, "postID")
+	if err != nil {
+		log.Warn("failed to get postID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input, err := readPostInput(c)
+	if err != nil {
+		abortPostInput(c, log, err)
+		return
+	}
+
+	req := &club.UpdatePostRequest{ClubID: access.ClubID, PostID: postID, ActorID: access.UserID}
+	if input.Text != nil {
+		req.Text = *input.Text
+		req.UpdateMask = append(req.UpdateMask, "text")
+	}
+	// a multipart edit always carries the full attachment list, an empty one removes them
+	if input.HasForm {
+		req.Images = input.Images
+		req.UpdateMask = append(req.UpdateMask, "attachments")
+	}
+
+	if len(req.UpdateMask) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "no fields to update"})
+		return
+	}
+
+	res, err := h.clbClient.UpdatePost(c, req)
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("post not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"post": domain.PostObjectToPost(res)})
+}
+
+// PinPostHandler pins the post on PUT and unpins it on DELETE.
+func (h *Handler) PinPostHandler(c *gin.Context) {
+	const op = "ClubHandler.PinPostHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	postID, err := utils.GetIntFromParams(c.Params, "postID")
+	if err != nil {
+		log.Warn("failed to get postID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.clbClient.PinPost(c, &club.PinPostRequest{
+		ClubID:  access.ClubID,
+		PostID:  postID,
+		ActorID: access.UserID,
+		Pinned:  c.Request.Method != http.MethodDelete,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Warn("cannot pin post", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("post not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"post": domain.PostObjectToPost(res)})
+}
+
+func (h *Handler) DeletePostHandler(c *gin.Context) {
+	const op = "ClubHandler.DeletePostHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	postID, err := utils.GetIntFromParams(c.Params, "postID")
+	if err != nil {
+		log.Warn("failed to get postID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err = h.clbClient.DeletePost(c, &club.PostRequest{ClubID: access.ClubID, PostID: postID, ActorID: access.UserID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("post not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// FeedHandler returns the posts of every club the caller belongs to, newest first.
+// Each club is asked for one page after the cursor and the pages are merged here.
+func (h *Handler) FeedHandler(c *gin.Context) {
+	const op = "ClubHandler.FeedHandler"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	cursor, limit, err := pageParams(c)
+	if err != nil {
+		log.Warn("invalid page parameters", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	clubsRes, err := h.clbClient.GetUserClubs(c, &clubv1.GetUserClubsRequest{UserId: userIDFromCtx.(int64)})
+	if err != nil {
+		log.Error("failed to get user clubs", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	clubs := clubsRes.GetClubs()
+	pages := make([][]club.PostObject, len(clubs))
+	errs := make([]error, len(clubs))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, feedConcurrency)
+	for i, clb := range clubs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, clubID int64) {
+			defer func() { <-sem; wg.Done() }()
+
+			res, err := h.clbClient.ListClubPosts(c, &club.ListClubPostsRequest{
+				ClubID:          clubID,
+				BeforeCreatedAt: cursor.CreatedAt,
+				BeforePostID:    cursor.PostID,
+				Limit:           int32(limit),
+			})
+			pages[i], errs[i] = res.GetPosts(), err
+		}(i, clb.GetClubId())
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		log.Error("failed to list club posts", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	posts := mergePosts(pages, limit)
+	c.JSON(http.StatusOK, gin.H{
+		"posts":       domain.MapPostObjArrToDomain(posts),
+		"next_cursor": nextCursor(posts, limit),
+	})
+}
+
+// pageParams parses the optional cursor and limit query parameters.
+func pageParams(c *gin.Context) (domain.PostCursor, int, error) {
+	var cursor domain.PostCursor
+	if s := c.Query("cursor"); s != "" {
+		var err error
+		if cursor, err = domain.DecodePostCursor(s); err != nil {
+			return cursor, 0, err
+		}
+	}
+
+	limit := defaultPostsLimit
+	if _, ok := c.GetQuery("limit"); ok {
+		l, err := utils.GetIntFromQuery(c, "limit")
+		if err != nil {
+			return cursor, 0, err
+		}
+		if l < 1 || l > maxPostsLimit {
+			return cursor, 0, errors.New("limit must be between 1 and " + strconv.Itoa(maxPostsLimit))
+		}
+		limit = l
+	}
+
+	return cursor, limit, nil
+}
+
+// nextCursor returns the cursor after the last post of a full page, or nil when there are no more posts.
+func nextCursor(posts []club.PostObject, limit int) *string {
+	if len(posts) < limit {
+		return nil
+	}
+	last := posts[len(posts)-1]
+	cursor := domain.PostCursor{CreatedAt: last.CreatedAt, PostID: last.PostID}.Encode()
+	return &cursor
+}
+
+// mergePosts merges pages that are each sorted newest first, keeping the newest limit posts.
+func mergePosts(pages [][]club.PostObject, limit int) []club.PostObject {
+	h := make(postHeap, 0, len(pages))
+	for _, page := range pages {
+		if len(page) > 0 {
+			h = append(h, page)
+		}
+	}
+	heap.Init(&h)
+
+	res := make([]club.PostObject, 0, limit)
+	for len(res) < limit && h.Len() > 0 {
+		page := h[0]
+		res = append(res, page[0])
+		if len(page) == 1 {
+			heap.Pop(&h)
+			continue
+		}
+		h[0] = page[1:]
+		heap.Fix(&h, 0)
+	}
+	return res
+}
+
+// postHeap orders non-empty pages by their first (newest) post.
+type postHeap [][]club.PostObject
+
+func (h postHeap) Len() int           { return len(h) }
+func (h postHeap) Less(i, j int) bool { return domain.NewerThan(&h[i][0], &h[j][0]) }
+func (h postHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *postHeap) Push(x any)        { *h = append(*h, x.([]club.PostObject)) }
+func (h *postHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+var errTooManyImages = errors.New("a post can have at most " + strconv.Itoa(maxPostImages) + " images")
+
+func readPostInput(c *gin.Context) (*postInput, error) {
+	if c.ContentType() != gin.MIMEMultipartPOSTForm {
+		var body struct {
+			Text *string `json:"text"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			return nil, err
+		}
+		if body.Text != nil && len([]rune(*body.Text)) > maxPostTextLength {
+			return nil, errPostTextTooLong
+		}
+		return &postInput{Text: body.Text}, nil
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxPostImages*maxPostImageSize+(1<<20))
+	form, err := c.MultipartForm()
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, errImageTooLarge
+		}
+		return nil, errImageUpload
+	}
+
+	input := &postInput{HasForm: true}
+	if text, ok := form.Value["text"]; ok && len(text) > 0 {
+		if len([]rune(text[0])) > maxPostTextLength {
+			return nil, errPostTextTooLong
+		}
+		input.Text = &text[0]
+	}
+
+	files := form.File["images"]
+	if len(files) > maxPostImages {
+		return nil, errTooManyImages
+	}
+	for _, fh := range files {
+		data, contentType, err := openImage(fh, maxPostImageSize)
+		if err != nil {
+			return nil, err
+		}
+		input.Images = append(input.Images, club.ImageUpload{Filename: fh.Filename, ContentType: contentType, Data: data})
+	}
+
+	return input, nil
+}
+
+func abortPostInput(c *gin.Context, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, errImageTooLarge), errors.Is(err, errImageType), errors.Is(err, errImageUpload):
+		abortImage(c, log, err)
+	default:
+		log.Warn("invalid post", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,278 @@
+package club
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+)
+
+const (
+	maxLogoSize   = 2 << 20
+	maxBannerSize = 5 << 20
+)
+
+// allowedImageTypes are the content types accepted for club logos and banners,
+// detected from the file content rather than the client supplied header.
+var allowedImageTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+	"image/webp": {},
+}
+
+var (
+	errImageTooLarge = errors.New("image is too large")
+	errImageType     = errors.New("image must be a jpeg, png or webp file")
+	errImageUpload   = errors.New("invalid file upload")
+)
+
+func (h *Handler) UpdateClubHandler(c *gin.Context) {
+	const op = "ClubHandler.UpdateClubHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input struct {
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		ClubType    string `json:"club_type,omitempty"`
+	}
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var paths []string
+	if input.Name != "" {
+		paths = append(paths, "name")
+	}
+	if input.Description != "" {
+		paths = append(paths, "description")
+	}
+	if input.ClubType != "" {
+		paths = append(paths, "club_type")
+	}
+
+	if len(paths) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "no fields to update"})
+		return
+	}
+
+	_, err = h.clbClient.UpdateClub(c, &clubv1.UpdateClubRequest{
+		ClubId:      access.ClubID,
+		UserId:      access.UserID,
+		Name:        input.Name,
+		Description: input.Description,
+		ClubType:    input.ClubType,
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: paths},
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.AlreadyExists:
+			log.Warn("club name already taken", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res, err := h.clbClient.GetClub(c, &clubv1.GetClubRequest{ClubId: access.ClubID})
+	if err != nil {
+		log.Error("failed to get updated club", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	details, err := h.clubDetails(c, res, nil, nil)
+	if err != nil {
+		log.Error("failed to get club details", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"club": details})
+}
+
+func (h *Handler) UpdateLogoHandler(c *gin.Context) {
+	const op = "ClubHandler.UpdateLogoHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	image, err := readImage(c, "logo", maxLogoSize)
+	if err != nil {
+		abortImage(c, log, err)
+		return
+	}
+
+	res, err := h.clbClient.UpdateLogo(c, &clubv1.UpdateLogoRequest{
+		ClubId: access.ClubID,
+		UserId: access.UserID,
+		Logo:   image,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	details, err := h.clubDetails(c, res, nil, nil)
+	if err != nil {
+		log.Error("failed to get club details", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"club": details})
+}
+
+func (h *Handler) UpdateBannerHandler(c *gin.Context) {
+	const op = "ClubHandler.UpdateBannerHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	image, err := readImage(c, "banner", maxBannerSize)
+	if err != nil {
+		abortImage(c, log, err)
+		return
+	}
+
+	res, err := h.clbClient.UpdateBanner(c, &club.UpdateBannerRequest{
+		ClubID: access.ClubID,
+		UserID: access.UserID,
+		Banner: image,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	details, err := h.clubDetails(c, res, nil, nil)
+	if err != nil {
+		log.Error("failed to get club details", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"club": details})
+}
+
+// readImage reads the multipart form file with the given field name, rejecting files
+// larger than maxSize and files whose detected content type is not an allowed image.
+func readImage(c *gin.Context, field string, maxSize int64) ([]byte, error) {
+	// leave room for the multipart envelope around the file itself
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+(1<<20))
+
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, errImageTooLarge
+		}
+		return nil, fmt.Errorf("%w: %w", errImageUpload, err)
+	}
+
+	data, _, err := openImage(fileHeader, maxSize)
+	return data, err
+}
+
+// openImage reads and validates an uploaded image, returning its content and detected content type.
+func openImage(fileHeader *multipart.FileHeader, maxSize int64) ([]byte, string, error) {
+	if fileHeader.Size > maxSize {
+		return nil, "", errImageTooLarge
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", errImageUpload, err)
+	}
+	defer file.Close()
+
+	buf := bytes.NewBuffer(make([]byte, 0, fileHeader.Size))
+	if _, err := io.Copy(buf, io.LimitReader(file, maxSize+1)); err != nil {
+		return nil, "", err
+	}
+	if int64(buf.Len()) > maxSize {
+		return nil, "", errImageTooLarge
+	}
+
+	contentType := http.DetectContentType(buf.Bytes())
+	if _, ok := allowedImageTypes[contentType]; !ok {
+		return nil, "", errImageType
+	}
+
+	return buf.Bytes(), contentType, nil
+}
+
+func abortImage(c *gin.Context, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, errImageTooLarge):
+		log.Warn("image too large")
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, errImageType):
+		log.Warn("unsupported image type")
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, errImageUpload):
+		log.Warn("failed to get image file from form", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": errImageUpload.Error()})
+	default:
+		log.Error("failed to read image", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,512 @@
+package club
+
+import (
+	"errors"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+)
+
+func (h *Handler) ListRolesHandler(c *gin.Context) {
+	const op = "ClubHandler.ListRolesHandler"
+	log := h.log.With(slog.String("op", op))
+
+	clubID, err := utils.GetIntFromParams(c.Params, "id")
+	if err != nil {
+		log.Warn("failed to get id params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.clbClient.ListClubRoles(c, &club.ListClubRolesRequest{ClubID: clubID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": domain.MapRoleObjArrToRoleArr(res.GetRoles())})
+}
+
+func (h *Handler) CreateRoleHandler(c *gin.Context) {
+	const op = "ClubHandler.CreateRoleHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input struct {
+		Name        string   `json:"name" binding:"required"`
+		Permissions []string `json:"permissions"`
+		Position    int32    `json:"position" binding:"min=0"`
+		Color       int32    `json:"color"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := access.CanManageRole(input.Position, input.Permissions); err != nil {
+		abortRoleCheck(c, log, err)
+		return
+	}
+
+	res, err := h.clbClient.CreateRole(c, &club.CreateRoleRequest{
+		ClubID:      access.ClubID,
+		ActorID:     access.UserID,
+		Name:        input.Name,
+		Permissions: input.Permissions,
+		Position:    input.Position,
+		Color:       input.Color,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.AlreadyExists:
+			log.Warn("role already exists", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("club not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"role": domain.RoleObjectToRole(*res)})
+}
+
+func (h *Handler) UpdateRoleHandler(c *gin.Context) {
+	const op = "ClubHandler.UpdateRoleHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	roleID, err := utils.GetIntFromParams(c.Params, "roleID")
+	if err != nil {
+		log.Warn("failed to get roleID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var input struct {
+		Name        *string   `json:"name"`
+		Permissions *[]string `json:"permissions"`
+		Position    *int32    `json:"position" binding:"omitempty,min=0"`
+		Color       *int32    `json:"color"`
+	}
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.findRole(c, access.ClubID, roleID)
+	if err != nil {
+		abortRoleLookup(c, log, err)
+		return
+	}
+
+	// the caller must be allowed to manage the role both before and after the update
+	if err := access.CanManageRole(role.Position, nil); err != nil {
+		abortRoleCheck(c, log, err)
+		return
+	}
+
+	req := &club.UpdateRoleRequest{ClubID: access.ClubID, RoleID: roleID, ActorID: access.UserID}
+	position, permissions := role.Position, role.Permissions
+	if input.Name != nil {
+		req.Name = *input.Name
+		req.UpdateMask = append(req.UpdateMask, "name")
+	}
+	if input.Permissions != nil {
+		req.Permissions = *input.Permissions
+		req.UpdateMask = append(req.UpdateMask, "permissions")
+		permissions = *input.Permissions
+	}
+	if input.Position != nil {
+		req.Position = *input.Position
+		req.UpdateMask = append(req.UpdateMask, "position")
+		position = *input.Position
+	}
+	if input.Color != nil {
+		req.Color = *input.Color
+		req.UpdateMask = append(req.UpdateMask, "color")
+	}
+
+	if len(req.UpdateMask) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "no fields to update"})
+		return
+	}
+
+	if err := access.CanManageRole(position, permissions); err != nil {
+		abortRoleCheck(c, log, err)
+		return
+	}
+
+	res, err := h.clbClient.UpdateRole(c, req)
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.AlreadyExists:
+			log.Warn("role already exists", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("role not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"role": domain.RoleObjectToRole(*res)})
+}
+
+func (h *Handler) ReorderRolesHandler(c *gin.Context) {
+	const op = "ClubHandler.ReorderRolesHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input struct {
+		Roles []struct {
+			RoleID   int64 `json:"role_id" binding:"required"`
+			Position int32 `json:"position" binding:"min=0"`
+		} `json:"roles" binding:"required,min=1,dive"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roles, err := h.clbClient.ListClubRoles(c, &club.ListClubRolesRequest{ClubID: access.ClubID})
+	if err != nil {
+		abortRoleLookup(c, log, err)
+		return
+	}
+	current := make(map[int64]club.RoleObject, len(roles.GetRoles()))
+	for _, role := range roles.GetRoles() {
+		current[role.RoleID] = role
+	}
+
+	positions := make([]club.RolePosition, len(input.Roles))
+	for i, r := range input.Roles {
+		role, ok := current[r.RoleID]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "role not found", "role_id": r.RoleID})
+			return
+		}
+		// a role can only be moved within the range below the caller's highest role
+		if err := access.CanManageRole(role.Position, nil); err != nil {
+			abortRoleCheck(c, log, err)
+			return
+		}
+		if err := access.CanManageRole(r.Position, nil); err != nil {
+			abortRoleCheck(c, log, err)
+			return
+		}
+		positions[i] = club.RolePosition{RoleID: r.RoleID, Position: r.Position}
+	}
+
+	res, err := h.clbClient.ReorderRoles(c, &club.ReorderRolesRequest{
+		ClubID:    access.ClubID,
+		ActorID:   access.UserID,
+		Positions: positions,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("role not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": domain.MapRoleObjArrToRoleArr(res.GetRoles())})
+}
+
+func (h *Handler) DeleteRoleHandler(c *gin.Context) {
+	const op = "ClubHandler.DeleteRoleHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	roleID, err := utils.GetIntFromParams(c.Params, "roleID")
+	if err != nil {
+		log.Warn("failed to get roleID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.findRole(c, access.ClubID, roleID)
+	if err != nil {
+		abortRoleLookup(c, log, err)
+		return
+	}
+
+	if err := access.CanManageRole(role.Position, nil); err != nil {
+		abortRoleCheck(c, log, err)
+		return
+	}
+
+	_, err = h.clbClient.DeleteRole(c, &club.DeleteRoleRequest{ClubID: access.ClubID, RoleID: roleID, ActorID: access.UserID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("role not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) AssignRoleHandler(c *gin.Context) {
+	const op = "ClubHandler.AssignRoleHandler"
+	log := h.log.With(slog.String("op", op))
+
+	var input struct {
+		RoleID int64 `json:"role_id" binding:"required"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req, ok := h.memberRoleRequest(c, log, input.RoleID)
+	if !ok {
+		return
+	}
+
+	_, err = h.clbClient.AssignRole(c, req)
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.AlreadyExists:
+			log.Warn("role already assigned", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("member or role not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+func (h *Handler) RevokeRoleHandler(c *gin.Context) {
+	const op = "ClubHandler.RevokeRoleHandler"
+	log := h.log.With(slog.String("op", op))
+
+	roleID, err := utils.GetIntFromParams(c.Params, "roleID")
+	if err != nil {
+		log.Warn("failed to get roleID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req, ok := h.memberRoleRequest(c, log, roleID)
+	if !ok {
+		return
+	}
+
+	_, err = h.clbClient.RevokeRole(c, req)
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("member role not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// memberRoleRequest builds an assign or revoke request for the :userID member after checking
+// that the caller outranks both the role and the member. It aborts the request on failure.
+func (h *Handler) memberRoleRequest(c *gin.Context, log *slog.Logger, roleID int64) (*club.MemberRoleRequest, bool) {
+	access, ok := accessFromContext(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return nil, false
+	}
+
+	targetID, err := utils.GetIntFromParams(c.Params, "userID")
+	if err != nil {
+		log.Warn("failed to get userID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	role, err := h.findRole(c, access.ClubID, roleID)
+	if err != nil {
+		abortRoleLookup(c, log, err)
+		return nil, false
+	}
+
+	if err := access.CanManageRole(role.Position, role.Permissions); err != nil {
+		abortRoleCheck(c, log, err)
+		return nil, false
+	}
+
+	target, err := h.clbClient.GetClubMember(c, &club.GetClubMemberRequest{ClubID: access.ClubID, UserID: targetID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("member not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "user is not a member of the club"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return nil, false
+	}
+
+	if targetID != access.UserID && !outranks(access, target) {
+		log.Warn("member outranks caller", slog.Int64("target_id", targetID))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you cannot change the roles of a member ranked at or above you"})
+		return nil, false
+	}
+
+	return &club.MemberRoleRequest{
+		ClubID:  access.ClubID,
+		UserID:  targetID,
+		RoleID:  roleID,
+		ActorID: access.UserID,
+	}, true
+}
+
+// findRole returns the role of the club, or a codes.NotFound status error.
+func (h *Handler) findRole(c *gin.Context, clubID, roleID int64) (*club.RoleObject, error) {
+	res, err := h.clbClient.ListClubRoles(c, &club.ListClubRolesRequest{ClubID: clubID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range res.GetRoles() {
+		if role.RoleID == roleID {
+			return &role, nil
+		}
+	}
+
+	return nil, status.Error(codes.NotFound, "role not found")
+}
+
+// outranks reports whether the caller ranks above the member. Admins and the owner
+// outrank everyone, nobody else outranks the owner.
+func outranks(access *domain.ClubAccess, member *club.MemberObject) bool {
+	if access.IsAdmin || access.IsOwner {
+		return true
+	}
+	if member.IsOwner {
+		return false
+	}
+	target := domain.ClubAccess{Roles: domain.MapRoleObjArrToRoleArr(member.Roles)}
+	return target.HighestPosition() < access.HighestPosition()
+}
+
+func accessFromContext(c *gin.Context) (*domain.ClubAccess, bool) {
+	access, ok := c.Get(ClubAccessKey)
+	if !ok {
+		return nil, false
+	}
+	return access.(*domain.ClubAccess), true
+}
+
+func abortRoleCheck(c *gin.Context, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUnknownPermission):
+		log.Warn("unknown permission", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error(), "allowed_permissions": domain.AllPermissions})
+	default:
+		log.Warn("role hierarchy violation", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	}
+}
+
+func abortRoleLookup(c *gin.Context, log *slog.Logger, err error) {
+	switch {
+	case status.Code(err) == codes.NotFound:
+		log.Warn("role not found", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+	default:
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}
+}
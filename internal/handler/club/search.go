@@ -0,0 +1,78 @@
+package club
+
+import (
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/gin-gonic/gin"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	clubSortFields = []string{club.SortByName, club.SortByMemberCount, club.SortByCreatedAt}
+	sortOrders     = []string{club.SortOrderAsc, club.SortOrderDesc}
+)
+
+// searchClubsRequest reads the search, filter and sort query parameters of the club list:
+//   - query: free-text search
+//   - club_types: repeated or comma-separated club types
+//   - open_membership: only clubs that do (true) or do not (false) accept join requests
+//   - min_members: only clubs with at least this many members
+//   - created_after: RFC 3339 timestamp or YYYY-MM-DD date
+//   - sort_by: name, member_count or created_at; order: asc (default) or desc
+func searchClubsRequest(c *gin.Context) (*club.SearchClubsRequest, error) {
+	req := &club.SearchClubsRequest{
+		Query:     strings.TrimSpace(c.Query("query")),
+		ClubTypes: utils.GetStringsFromQuery(c, "club_types"),
+	}
+
+	openMembership, err := utils.GetBoolFromQuery(c, "open_membership")
+	if err != nil {
+		return nil, err
+	}
+	req.OpenMembership = openMembership
+
+	if q := c.Query("min_members"); q != "" {
+		minMembers, err := strconv.ParseInt(q, 10, 64)
+		if err != nil || minMembers < 0 {
+			return nil, fmt.Errorf("min_members query parameter must be a non-negative integer")
+		}
+		req.MinMembers = minMembers
+	}
+
+	createdAfter, err := utils.GetTimeFromQuery(c, "created_after")
+	if err != nil {
+		return nil, err
+	}
+	if !createdAfter.IsZero() {
+		req.CreatedAfter = &createdAfter
+	}
+
+	if sortBy := c.Query("sort_by"); sortBy != "" {
+		if !slices.Contains(clubSortFields, sortBy) {
+			return nil, fmt.Errorf("sort_by query parameter must be one of %s", strings.Join(clubSortFields, ", "))
+		}
+		req.SortBy = sortBy
+		req.SortOrder = club.SortOrderAsc
+	}
+
+	if order := strings.ToLower(c.Query("order")); order != "" {
+		if !slices.Contains(sortOrders, order) {
+			return nil, fmt.Errorf("order query parameter must be one of %s", strings.Join(sortOrders, ", "))
+		}
+		if req.SortBy == "" {
+			return nil, fmt.Errorf("order query parameter requires sort_by")
+		}
+		req.SortOrder = order
+	}
+
+	return req, nil
+}
+
+// needsSearch reports whether the request filters or sorts beyond query and club_types,
+// which only SearchClubs supports. Other club lists go to ListClubs.
+func needsSearch(req *club.SearchClubsRequest) bool {
+	return req.OpenMembership != nil || req.MinMembers > 0 || req.CreatedAfter != nil || req.SortBy != ""
+}
@@ -0,0 +1,42 @@
+package club
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/webhook"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"log/slog"
+)
+
+// dispatchWebhook queues the event for the registered webhook endpoints in the background.
+// Like notify, it never fails the request that triggered it.
+func (h *Handler) dispatchWebhook(eventType string, data map[string]any) {
+	if h.webhooks == nil {
+		return
+	}
+
+	const op = "ClubHandler.dispatchWebhook"
+	log := h.log.With(slog.String("op", op), slog.String("event", eventType))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		if err := h.webhooks.Dispatch(ctx, eventType, data); err != nil {
+			log.Error("failed to dispatch webhook", logger.Err(err))
+		}
+	}()
+}
+
+func clubDecisionWebhook(approved bool) string {
+	if approved {
+		return webhook.EventClubApproved
+	}
+	return webhook.EventClubRejected
+}
+
+func membershipDecisionWebhook(approved bool) string {
+	if approved {
+		return webhook.EventMembershipApproved
+	}
+	return webhook.EventMembershipRejected
+}
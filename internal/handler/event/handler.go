@@ -0,0 +1,461 @@
+package event
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Handler struct {
+	evtClient *event.Client
+	log       *slog.Logger
+}
+
+// New creates and returns a new Event Handler instance
+// Parameters:
+//   - client: A *event.Client which is a gRPC client for the event service.
+//   - log: A *slog.Logger used for logging messages and errors.
+//
+// Returns:
+//   - A Handler struct that encapsulates the provided service client and logger.
+func New(client *event.Client, log *slog.Logger) Handler {
+	return Handler{
+		evtClient: client,
+		log:       log,
+	}
+}
+
+func (h *Handler) ListEventsHandler(c *gin.Context) {
+	const op = "EventHandler.ListEventsHandler"
+	log := h.log.With(slog.String("op", op))
+
+	var clubID int64
+	if c.Param("id") != "" {
+		id, err := utils.GetIntFromParams(c.Params, "id")
+		if err != nil {
+			log.Warn("failed to get id params", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		clubID = id
+	} else if _, ok := c.GetQuery("club_id"); ok {
+		id, err := utils.GetIntFromQuery(c, "club_id")
+		if err != nil {
+			log.Warn("failed to get club_id query parameter", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		clubID = int64(id)
+	}
+
+	from, err := utils.GetTimeFromQuery(c, "from")
+	if err != nil {
+		log.Warn("failed to get from query parameter", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	to, err := utils.GetTimeFromQuery(c, "to")
+	if err != nil {
+		log.Warn("failed to get to query parameter", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return
+	}
+
+	page, err := utils.GetIntFromQuery(c, "page")
+	if err != nil {
+		log.Warn("failed to get page query parameter", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pageSize, err := utils.GetIntFromQuery(c, "page_size")
+	if err != nil {
+		log.Warn("failed to get page_size query parameter", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.evtClient.ListEvents(c, &event.ListEventsRequest{
+		ClubID:       clubID,
+		From:         from,
+		To:           to,
+		UpcomingOnly: c.Query("upcoming") == "true",
+		Page:         int32(page),
+		PageSize:     int32(pageSize),
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events":   domain.MapEventObjArrToDomain(res.GetEvents()),
+		"metadata": domain.NewPagination(int32(page), int32(pageSize), res.GetTotalCount()),
+	})
+}
+
+func (h *Handler) GetEventHandler(c *gin.Context) {
+	const op = "EventHandler.GetEventHandler"
+	log := h.log.With(slog.String("op", op))
+
+	eventID, err := utils.GetIntFromParams(c.Params, "eventID")
+	if err != nil {
+		log.Warn("failed to get eventID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.evtClient.GetEvent(c, &event.GetEventRequest{EventID: eventID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("event not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"event": domain.EventObjectToEvent(res)})
+}
+
+func (h *Handler) CreateEventHandler(c *gin.Context) {
+	const op = "EventHandler.CreateEventHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := clubAccess(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input struct {
+		Title       string    `json:"title" binding:"required"`
+		Description string    `json:"description"`
+		Location    string    `json:"location"`
+		StartsAt    time.Time `json:"starts_at" binding:"required"`
+		EndsAt      time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+		Capacity    int32     `json:"capacity" binding:"min=0"`
+	}
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.evtClient.CreateEvent(c, &event.CreateEventRequest{
+		ClubID:      access.ClubID,
+		CreatorID:   access.UserID,
+		Title:       input.Title,
+		Description: input.Description,
+		Location:    input.Location,
+		StartsAt:    input.StartsAt,
+		EndsAt:      input.EndsAt,
+		Capacity:    input.Capacity,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"event": domain.EventObjectToEvent(res)})
+}
+
+func (h *Handler) UpdateEventHandler(c *gin.Context) {
+	const op = "EventHandler.UpdateEventHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := clubAccess(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	eventID, ok := h.clubEventID(c, log, access.ClubID)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Title       *string    `json:"title"`
+		Description *string    `json:"description"`
+		Location    *string    `json:"location"`
+		StartsAt    *time.Time `json:"starts_at"`
+		EndsAt      *time.Time `json:"ends_at"`
+		Capacity    *int32     `json:"capacity" binding:"omitempty,min=0"`
+	}
+
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &event.UpdateEventRequest{EventID: eventID, ActorID: access.UserID}
+	if input.Title != nil {
+		req.Title = *input.Title
+		req.UpdateMask = append(req.UpdateMask, "title")
+	}
+	if input.Description != nil {
+		req.Description = *input.Description
+		req.UpdateMask = append(req.UpdateMask, "description")
+	}
+	if input.Location != nil {
+		req.Location = *input.Location
+		req.UpdateMask = append(req.UpdateMask, "location")
+	}
+	if input.StartsAt != nil {
+		req.StartsAt = *input.StartsAt
+		req.UpdateMask = append(req.UpdateMask, "starts_at")
+	}
+	if input.EndsAt != nil {
+		req.EndsAt = *input.EndsAt
+		req.UpdateMask = append(req.UpdateMask, "ends_at")
+	}
+	if input.Capacity != nil {
+		req.Capacity = *input.Capacity
+		req.UpdateMask = append(req.UpdateMask, "capacity")
+	}
+
+	if len(req.UpdateMask) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "no fields to update"})
+		return
+	}
+
+	res, err := h.evtClient.UpdateEvent(c, req)
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Warn("event cannot be updated", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("event not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"event": domain.EventObjectToEvent(res)})
+}
+
+func (h *Handler) CancelEventHandler(c *gin.Context) {
+	const op = "EventHandler.CancelEventHandler"
+	log := h.log.With(slog.String("op", op))
+
+	access, ok := clubAccess(c)
+	if !ok {
+		log.Warn("club access not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	eventID, ok := h.clubEventID(c, log, access.ClubID)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Reason string `json:"reason" binding:"max=500"`
+	}
+	// the body is optional
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			log.Error("decoding err", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	res, err := h.evtClient.CancelEvent(c, &event.CancelEventRequest{
+		EventID: eventID,
+		ActorID: access.UserID,
+		Reason:  input.Reason,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Warn("event cannot be cancelled", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("event not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"event": domain.EventObjectToEvent(res)})
+}
+
+func (h *Handler) RSVPHandler(c *gin.Context) {
+	const op = "EventHandler.RSVPHandler"
+	log := h.log.With(slog.String("op", op))
+
+	eventID, err := utils.GetIntFromParams(c.Params, "eventID")
+	if err != nil {
+		log.Warn("failed to get eventID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input struct {
+		Status string `json:"status" binding:"required,oneof=going not_going"`
+	}
+
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.evtClient.RSVP(c, &event.RSVPRequest{
+		EventID: eventID,
+		UserID:  userIDFromCtx.(int64),
+		Status:  input.Status,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Warn("rsvp closed", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("event not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rsvp": domain.RSVPObjectToRSVP(res)})
+}
+
+func (h *Handler) GetRSVPHandler(c *gin.Context) {
+	const op = "EventHandler.GetRSVPHandler"
+	log := h.log.With(slog.String("op", op))
+
+	eventID, err := utils.GetIntFromParams(c.Params, "eventID")
+	if err != nil {
+		log.Warn("failed to get eventID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	res, err := h.evtClient.GetRSVP(c, &event.GetRSVPRequest{EventID: eventID, UserID: userIDFromCtx.(int64)})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("rsvp not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rsvp": domain.RSVPObjectToRSVP(res)})
+}
+
+// clubEventID returns the :eventID parameter after checking that the event belongs to
+// the club, so club permissions cannot be used on events of other clubs.
+func (h *Handler) clubEventID(c *gin.Context, log *slog.Logger, clubID int64) (int64, bool) {
+	eventID, err := utils.GetIntFromParams(c.Params, "eventID")
+	if err != nil {
+		log.Warn("failed to get eventID params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return 0, false
+	}
+
+	res, err := h.evtClient.GetEvent(c, &event.GetEventRequest{EventID: eventID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("event not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return 0, false
+	}
+
+	if res.ClubID != clubID {
+		log.Warn("event belongs to another club", slog.Int64("event_id", eventID), slog.Int64("club_id", clubID))
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "event not found"})
+		return 0, false
+	}
+
+	return eventID, true
+}
+
+// clubAccess returns the caller's access set by club.Handler.ClubPermissionMiddleware.
+func clubAccess(c *gin.Context) (*domain.ClubAccess, bool) {
+	access, ok := c.Get(club.ClubAccessKey)
+	if !ok {
+		return nil, false
+	}
+	return access.(*domain.ClubAccess), true
+}
@@ -0,0 +1,242 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/event/fake"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/club"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	testClubID = 1
+	testUserID = 100
+)
+
+type testServer struct {
+	router *gin.Engine
+	client *event.Client
+}
+
+// newTestServer routes the event handlers to the in-process fake. Every request is
+// authenticated as the user in the X-User header and may manage events of testClubID.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	client, _, stop, err := fake.StartInProcess(context.Background(), log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+
+	h := New(client, log)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID, err := strconv.ParseInt(c.GetHeader("X-User"), 10, 64)
+		if err != nil {
+			userID = testUserID
+		}
+		c.Set("userID", userID)
+		c.Set(club.ClubAccessKey, &domain.ClubAccess{ClubID: testClubID, UserID: userID, IsOwner: true})
+	})
+	router.GET("/events", h.ListEventsHandler)
+	router.GET("/clubs/:id/events", h.ListEventsHandler)
+	router.POST("/clubs/:id/events", h.CreateEventHandler)
+	router.POST("/clubs/:id/events/:eventID/cancel", h.CancelEventHandler)
+	router.POST("/events/:eventID/rsvp", h.RSVPHandler)
+	router.GET("/events/:eventID/rsvp", h.GetRSVPHandler)
+
+	return &testServer{router: router, client: client}
+}
+
+func (s *testServer) do(t *testing.T, method, path string, userID int64, body any, out any) int {
+	t.Helper()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req := httptest.NewRequest(method, path, r)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", strconv.FormatInt(userID, 10))
+
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+
+	if out != nil && res.Code < 300 {
+		if err := json.Unmarshal(res.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: %v, body = %s", method, path, err, res.Body)
+		}
+	}
+	return res.Code
+}
+
+func (s *testServer) createEvent(t *testing.T, clubID int64, startsIn time.Duration, capacity int32) domain.Event {
+	t.Helper()
+
+	startsAt := time.Now().Add(startsIn).UTC().Truncate(time.Second)
+	res, err := s.client.CreateEvent(context.Background(), &event.CreateEventRequest{
+		ClubID:   clubID,
+		Title:    "Meeting",
+		StartsAt: startsAt,
+		EndsAt:   startsAt.Add(time.Hour),
+		Capacity: capacity,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *domain.EventObjectToEvent(res)
+}
+
+func TestCreateEventHandler(t *testing.T) {
+	s := newTestServer(t)
+	startsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	var res struct {
+		Event domain.Event `json:"event"`
+	}
+	code := s.do(t, http.MethodPost, "/clubs/1/events", testUserID, gin.H{
+		"title":     "Chess night",
+		"starts_at": startsAt,
+		"ends_at":   startsAt.Add(2 * time.Hour),
+		"capacity":  10,
+	}, &res)
+	if code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", code, http.StatusCreated)
+	}
+	if res.Event.ClubID != testClubID || res.Event.CreatorID != testUserID || res.Event.SeatsLeft == nil || *res.Event.SeatsLeft != 10 {
+		t.Fatalf("event = %+v", res.Event)
+	}
+
+	code = s.do(t, http.MethodPost, "/clubs/1/events", testUserID, gin.H{
+		"title":     "Backwards",
+		"starts_at": startsAt,
+		"ends_at":   startsAt.Add(-time.Hour),
+	}, nil)
+	if code != http.StatusBadRequest {
+		t.Fatalf("ends before start: status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestRSVPHandlerWaitlist(t *testing.T) {
+	s := newTestServer(t)
+	e := s.createEvent(t, testClubID, 24*time.Hour, 1)
+	path := "/events/" + strconv.FormatInt(e.ID, 10) + "/rsvp"
+
+	var rsvp struct {
+		RSVP domain.RSVP `json:"rsvp"`
+	}
+	if code := s.do(t, http.MethodPost, path, 1, gin.H{"status": "going"}, &rsvp); code != http.StatusOK || rsvp.RSVP.Status != event.RSVPGoing {
+		t.Fatalf("first rsvp: status %d, %+v", code, rsvp.RSVP)
+	}
+	if code := s.do(t, http.MethodPost, path, 2, gin.H{"status": "going"}, &rsvp); code != http.StatusOK || rsvp.RSVP.Status != event.RSVPWaitlisted || rsvp.RSVP.WaitlistPosition != 1 {
+		t.Fatalf("second rsvp: status %d, %+v", code, rsvp.RSVP)
+	}
+
+	s.do(t, http.MethodPost, path, 1, gin.H{"status": "not_going"}, nil)
+
+	if code := s.do(t, http.MethodGet, path, 2, nil, &rsvp); code != http.StatusOK || rsvp.RSVP.Status != event.RSVPGoing {
+		t.Fatalf("after a seat freed up: status %d, %+v", code, rsvp.RSVP)
+	}
+	if code := s.do(t, http.MethodGet, path, 3, nil, nil); code != http.StatusNotFound {
+		t.Fatalf("user without rsvp: status = %d, want %d", code, http.StatusNotFound)
+	}
+	if code := s.do(t, http.MethodPost, path, 3, gin.H{"status": "maybe"}, nil); code != http.StatusBadRequest {
+		t.Fatalf("invalid answer: status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestCancelEventHandler(t *testing.T) {
+	s := newTestServer(t)
+	e := s.createEvent(t, testClubID, 24*time.Hour, 0)
+	other := s.createEvent(t, 2, 24*time.Hour, 0)
+
+	cancelPath := func(id int64) string { return "/clubs/1/events/" + strconv.FormatInt(id, 10) + "/cancel" }
+
+	if code := s.do(t, http.MethodPost, cancelPath(other.ID), testUserID, nil, nil); code != http.StatusNotFound {
+		t.Fatalf("event of another club: status = %d, want %d", code, http.StatusNotFound)
+	}
+
+	var res struct {
+		Event domain.Event `json:"event"`
+	}
+	if code := s.do(t, http.MethodPost, cancelPath(e.ID), testUserID, gin.H{"reason": "exams"}, &res); code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", code, http.StatusOK)
+	}
+	if res.Event.Status != event.StatusCancelled || res.Event.CancelReason != "exams" {
+		t.Fatalf("event = %+v", res.Event)
+	}
+
+	if code := s.do(t, http.MethodPost, cancelPath(e.ID), testUserID, nil, nil); code != http.StatusConflict {
+		t.Fatalf("cancel twice: status = %d, want %d", code, http.StatusConflict)
+	}
+	rsvpPath := "/events/" + strconv.FormatInt(e.ID, 10) + "/rsvp"
+	if code := s.do(t, http.MethodPost, rsvpPath, 1, gin.H{"status": "going"}, nil); code != http.StatusConflict {
+		t.Fatalf("rsvp to a cancelled event: status = %d, want %d", code, http.StatusConflict)
+	}
+}
+
+func TestListEventsHandlerFilters(t *testing.T) {
+	s := newTestServer(t)
+	past := s.createEvent(t, testClubID, -48*time.Hour, 0)
+	soon := s.createEvent(t, testClubID, 24*time.Hour, 0)
+	later := s.createEvent(t, testClubID, 72*time.Hour, 0)
+	other := s.createEvent(t, 2, 24*time.Hour, 0)
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantIDs  []int64
+	}{
+		{"club path", "/clubs/1/events?page=1&page_size=10", http.StatusOK, []int64{past.ID, soon.ID, later.ID}},
+		{"club_id query", "/events?club_id=2&page=1&page_size=10", http.StatusOK, []int64{other.ID}},
+		{"upcoming", "/clubs/1/events?upcoming=true&page=1&page_size=10", http.StatusOK, []int64{soon.ID, later.ID}},
+		{"from", "/clubs/1/events?from=" + soon.StartsAt.Format(time.RFC3339) + "&page=1&page_size=10", http.StatusOK, []int64{soon.ID, later.ID}},
+		{"to before from", "/events?from=2030-01-02T00:00:00Z&to=2030-01-01T00:00:00Z", http.StatusBadRequest, nil},
+		{"invalid from", "/events?from=yesterday", http.StatusBadRequest, nil},
+		{"paged", "/clubs/1/events?page=2&page_size=2", http.StatusOK, []int64{later.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res struct {
+				Events []domain.Event `json:"events"`
+			}
+			code := s.do(t, http.MethodGet, tt.path, testUserID, nil, &res)
+			if code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", code, tt.wantCode)
+			}
+
+			var ids []int64
+			for _, e := range res.Events {
+				ids = append(ids, e.ID)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("events = %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("events = %v, want %v", ids, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
@@ -2,25 +2,79 @@ package handler
 
 import (
 	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/apikey"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/attendance"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/auth"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
 	clubgrpc "github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	eventgrpc "github.com/ARUMANDESU/university-clubs-backend/internal/clients/event"
+	notificationgrpc "github.com/ARUMANDESU/university-clubs-backend/internal/clients/notification"
 	usergrpc "github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/admin"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/event"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/middleware"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/notification"
+	realtimehandler "github.com/ARUMANDESU/university-clubs-backend/internal/handler/realtime"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/oidc"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/realtime"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/webhook"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/ratelimit"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"log/slog"
+	"net/http"
+	"time"
 )
 
 type Handler struct {
-	UsrHandler  user.Handler
-	ClubHandler club.Handler
+	UsrHandler   user.Handler
+	ClubHandler  club.Handler
+	EventHandler event.Handler
+	NtfHandler   notification.Handler
+	RTHandler    realtimehandler.Handler
+	AdminHandler admin.Handler
+	CSRF         *middleware.CSRF
+	APIKeys      *middleware.APIKeyAuth
+	limiter      *ratelimit.Limiter
+	cfg          *config.Config
 }
 
-func New(log *slog.Logger, usrClient *usergrpc.Client, clubClient *clubgrpc.Client) *Handler {
+func New(
+	cfg *config.Config,
+	log *slog.Logger,
+	usrClient *usergrpc.Client,
+	clubClient *clubgrpc.Client,
+	eventClient *eventgrpc.Client,
+	notificationClient *notificationgrpc.Client,
+	hub *realtime.Hub,
+	tokens *auth.TokenIssuer,
+	apiKeys *apikey.Service,
+	webhooks *webhook.Service,
+	outbox *broker.Outbox,
+	attendance *attendance.Service,
+) *Handler {
+	limiter := ratelimit.New()
+
+	var oidcProvider *oidc.Provider
+	if cfg.OIDC.Enabled {
+		oidcProvider = oidc.NewProvider(cfg.OIDC)
+	}
 
 	return &Handler{
-		UsrHandler:  user.New(usrClient, log),
-		ClubHandler: club.New(clubClient, log),
+		UsrHandler:   user.New(usrClient, clubClient, cfg.Session, tokens, oidcProvider, limiter, outbox, log),
+		ClubHandler:  club.New(clubClient, usrClient, notificationClient, hub, webhooks, outbox, attendance, log),
+		EventHandler: event.New(eventClient, log),
+		NtfHandler:   notification.New(notificationClient, log),
+		RTHandler:    realtimehandler.New(hub, clubClient, cfg.Realtime.HeartbeatInterval, log),
+		AdminHandler: admin.New(apiKeys, webhooks, log),
+		CSRF:         middleware.NewCSRF(cfg.CSRF, cfg.AllowedOrigins, cfg.Session, log),
+		APIKeys:      middleware.NewAPIKeyAuth(apiKeys, limiter, log),
+		limiter:      limiter,
+		cfg:          cfg,
 	}
 }
 
@@ -28,11 +82,14 @@ func (h *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
 
 	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"http://localhost:3000"}
+	config.AllowOrigins = h.cfg.AllowedOrigins
 	config.AllowCredentials = true
+	config.AddAllowHeaders("Authorization", middleware.APIKeyHeader, h.cfg.CSRF.HeaderName)
 
 	router.Use(cors.New(config))
 	router.Use(gin.Logger(), gin.Recovery())
+	router.Use(h.CSRF.Middleware())
+	router.Use(h.APIKeys.Middleware())
 
 	auth := router.Group("/auth")
 	{
@@ -40,6 +97,23 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		auth.POST("/sign-in", h.UsrHandler.SignIn)
 		auth.POST("/logout", h.UsrHandler.Logout)
 		auth.POST("/activate", h.UsrHandler.Activate)
+		auth.POST("/activate/code", middleware.RateLimit(h.limiter, "activate-code", 20, 15*time.Minute), h.UsrHandler.ActivateByCode)
+		auth.POST("/activate/resend", middleware.RateLimit(h.limiter, "activate-resend", 5, 15*time.Minute), h.UsrHandler.ResendActivation)
+		auth.GET("/activate/status", middleware.RateLimit(h.limiter, "activate-status", 30, time.Minute), h.UsrHandler.ActivationStatus)
+		auth.GET("/csrf", h.CSRF.IssueToken)
+		auth.POST("/token", h.UsrHandler.SignInToken)
+		auth.POST("/token/refresh", h.UsrHandler.RefreshToken)
+		auth.GET("/oidc/login", h.UsrHandler.OIDCLogin)
+		auth.GET("/oidc/callback", h.UsrHandler.OIDCCallback)
+		auth.POST("/password/forgot", middleware.RateLimit(h.limiter, "password-forgot", 5, 15*time.Minute), h.UsrHandler.ForgotPassword)
+		auth.POST("/email/confirm", h.UsrHandler.ConfirmEmailChange)
+		auth.POST("/password/reset", middleware.RateLimit(h.limiter, "password-reset", 10, 15*time.Minute), h.UsrHandler.ResetPassword)
+	}
+
+	mePath := router.Group("/me")
+	{
+		mePath.Use(h.UsrHandler.SessionAuthMiddleware())
+		mePath.GET("", h.UsrHandler.Me)
 	}
 
 	userPath := router.Group("/user")
@@ -53,6 +127,8 @@ func (h *Handler) InitRoutes() *gin.Engine {
 
 			userPathAuth.PATCH("/:id", h.UsrHandler.UpdateUser)
 			userPathAuth.PATCH("/:id/avatar", h.UsrHandler.UpdateAvatar)
+			userPathAuth.PATCH("/:id/password", h.UsrHandler.ChangePassword)
+			userPathAuth.POST("/:id/email", h.UsrHandler.RequestEmailChange)
 
 			userPathAuth.DELETE("/:id", h.UsrHandler.DeleteUser)
 		}
@@ -64,6 +140,9 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		clubPath.GET("/", h.ClubHandler.ListClubsHandler)
 		clubPath.GET("/:id/members", h.ClubHandler.ListClubMembersHandler)
 		clubPath.GET("/:id", h.ClubHandler.GetClubHandler)
+		clubPath.GET("/:id/roles", h.ClubHandler.ListRolesHandler)
+		clubPath.GET("/:id/events", h.EventHandler.ListEventsHandler)
+		clubPath.GET("/:id/posts", h.ClubHandler.ListPostsHandler)
 
 		clubPathAuth := clubPath.Group("")
 		{
@@ -71,14 +150,104 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			clubPathAuth.POST("/:id", h.UsrHandler.RoleAuthMiddleware([]userv1.Role{userv1.Role_DSVR, userv1.Role_ADMIN}), h.ClubHandler.NewClubHandler)
 			clubPathAuth.GET("/pending", h.UsrHandler.RoleAuthMiddleware([]userv1.Role{userv1.Role_DSVR, userv1.Role_ADMIN}), h.ClubHandler.ListNewClubRequestsHandler)
 
-			clubPathAuth.POST("/:id/members", h.ClubHandler.HandleJoinRequestHandler)
-			clubPathAuth.GET("/:id/join", h.ClubHandler.ListJoinRequestsHandler)
+			clubPathAuth.POST("/:id/members", h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManageMembers), h.ClubHandler.HandleJoinRequestHandler)
+			clubPathAuth.GET("/:id/join", h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManageMembers), h.ClubHandler.ListJoinRequestsHandler)
 			clubPathAuth.POST("/:id/join", h.ClubHandler.JoinRequestHandler)
 			clubPathAuth.POST("/", h.ClubHandler.CreateClubHandler)
+			clubPathAuth.GET("/:id/members/export", h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManageMembers), h.ClubHandler.ExportMembersHandler)
+			clubPathAuth.POST("/:id/members/import", h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManageMembers), h.ClubHandler.ImportMembersHandler)
+			clubPathAuth.DELETE("/:id/members/me", h.ClubHandler.LeaveClubHandler)
+			clubPathAuth.DELETE("/:id/members/:userID", h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManageMembers), h.ClubHandler.RemoveMemberHandler)
+			clubPathAuth.POST("/:id/transfer-ownership", middleware.RateLimit(h.limiter, "transfer-ownership", 5, 15*time.Minute), h.ClubHandler.TransferOwnershipHandler)
+
+			editClub := h.ClubHandler.ClubPermissionMiddleware(domain.PermissionEditClub)
+			clubPathAuth.PATCH("/:id", editClub, h.ClubHandler.UpdateClubHandler)
+			clubPathAuth.PUT("/:id/logo", editClub, h.ClubHandler.UpdateLogoHandler)
+			clubPathAuth.PUT("/:id/banner", editClub, h.ClubHandler.UpdateBannerHandler)
+
+			manageEvents := h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManageEvents)
+			clubPathAuth.POST("/:id/events", manageEvents, h.EventHandler.CreateEventHandler)
+			clubPathAuth.PATCH("/:id/events/:eventID", manageEvents, h.EventHandler.UpdateEventHandler)
+			clubPathAuth.POST("/:id/events/:eventID/cancel", manageEvents, h.EventHandler.CancelEventHandler)
+			clubPathAuth.POST("/:id/attendance", manageEvents, h.ClubHandler.OpenAttendanceHandler)
+			clubPathAuth.GET("/:id/attendance", manageEvents, h.ClubHandler.ListAttendanceHandler)
+			clubPathAuth.GET("/:id/attendance/:sessionID", manageEvents, h.ClubHandler.GetAttendanceHandler)
+			clubPathAuth.POST("/:id/attendance/:sessionID/check-in", manageEvents, h.ClubHandler.CheckInHandler)
+			clubPathAuth.POST("/:id/attendance/:sessionID/close", manageEvents, h.ClubHandler.CloseAttendanceHandler)
+			clubPathAuth.GET("/:id/attendance/:sessionID/export", manageEvents, h.ClubHandler.ExportAttendanceHandler)
+
+			managePosts := h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManagePosts)
+			clubPathAuth.POST("/:id/posts", managePosts, h.ClubHandler.CreatePostHandler)
+			clubPathAuth.PATCH("/:id/posts/:postID", managePosts, h.ClubHandler.UpdatePostHandler)
+			clubPathAuth.DELETE("/:id/posts/:postID", managePosts, h.ClubHandler.DeletePostHandler)
+			clubPathAuth.PUT("/:id/posts/:postID/pin", managePosts, h.ClubHandler.PinPostHandler)
+			clubPathAuth.DELETE("/:id/posts/:postID/pin", managePosts, h.ClubHandler.PinPostHandler)
+
+			manageRoles := h.ClubHandler.ClubPermissionMiddleware(domain.PermissionManageRoles)
+			clubPathAuth.POST("/:id/roles", manageRoles, h.ClubHandler.CreateRoleHandler)
+			clubPathAuth.PUT("/:id/roles/order", manageRoles, h.ClubHandler.ReorderRolesHandler)
+			clubPathAuth.PATCH("/:id/roles/:roleID", manageRoles, h.ClubHandler.UpdateRoleHandler)
+			clubPathAuth.DELETE("/:id/roles/:roleID", manageRoles, h.ClubHandler.DeleteRoleHandler)
+			clubPathAuth.POST("/:id/members/:userID/roles", manageRoles, h.ClubHandler.AssignRoleHandler)
+			clubPathAuth.DELETE("/:id/members/:userID/roles/:roleID", manageRoles, h.ClubHandler.RevokeRoleHandler)
+		}
+
+	}
+
+	notificationPath := router.Group("/notifications")
+	{
+		notificationPath.Use(h.UsrHandler.SessionAuthMiddleware())
+		notificationPath.GET("", h.NtfHandler.ListNotifications)
+		notificationPath.GET("/unread-count", h.NtfHandler.UnreadCount)
+		notificationPath.POST("/read-all", h.NtfHandler.MarkAllRead)
+		notificationPath.PUT("/:id/read", h.NtfHandler.MarkRead)
+		notificationPath.DELETE("/:id/read", h.NtfHandler.MarkRead)
+	}
+
+	feedPath := router.Group("/feed")
+	{
+		feedPath.Use(h.UsrHandler.SessionAuthMiddleware())
+		feedPath.GET("", h.ClubHandler.FeedHandler)
+	}
+
+	eventPath := router.Group("/events")
+	{
+		eventPath.GET("/", h.EventHandler.ListEventsHandler)
+		eventPath.GET("/:eventID", h.EventHandler.GetEventHandler)
+
+		eventPathAuth := eventPath.Group("")
+		{
+			eventPathAuth.Use(h.UsrHandler.SessionAuthMiddleware())
+			eventPathAuth.GET("/stream", h.RTHandler.Stream)
+			eventPathAuth.GET("/:eventID/rsvp", h.EventHandler.GetRSVPHandler)
+			eventPathAuth.PUT("/:eventID/rsvp", h.EventHandler.RSVPHandler)
 		}
+	}
+
+	adminPath := router.Group("/admin")
+	{
+		adminPath.Use(h.UsrHandler.SessionAuthMiddleware(), h.UsrHandler.RoleAuthMiddleware([]userv1.Role{userv1.Role_ADMIN}))
 
+		adminPath.POST("/api-keys", h.AdminHandler.CreateAPIKey)
+		adminPath.GET("/api-keys", h.AdminHandler.ListAPIKeys)
+		adminPath.DELETE("/api-keys/:id", h.AdminHandler.RevokeAPIKey)
+
+		adminPath.POST("/webhooks", h.AdminHandler.CreateWebhook)
+		adminPath.GET("/webhooks", h.AdminHandler.ListWebhooks)
+		adminPath.DELETE("/webhooks/:id", h.AdminHandler.DeleteWebhook)
+		adminPath.GET("/webhooks/dead-letters", h.AdminHandler.ListDeadLetters)
+		adminPath.POST("/webhooks/deliveries/:id/replay", h.AdminHandler.ReplayDelivery)
 	}
 
+	// routes reachable with API keys, every other route rejects them
+	h.APIKeys.Allow(http.MethodGet, "/clubs/", apikey.ScopeClubsRead)
+	h.APIKeys.Allow(http.MethodGet, "/clubs/:id", apikey.ScopeClubsRead)
+	h.APIKeys.Allow(http.MethodGet, "/clubs/:id/members", apikey.ScopeClubsRead)
+	h.APIKeys.Allow(http.MethodGet, "/clubs/pending", apikey.ScopeClubsApprove)
+	h.APIKeys.Allow(http.MethodPost, "/clubs/:id", apikey.ScopeClubsApprove)
+	h.APIKeys.Allow(http.MethodGet, "/user/:id", apikey.ScopeUsersRead)
+	h.APIKeys.Allow(http.MethodGet, "/user/search", apikey.ScopeUsersRead)
+
 	//TODO: implement other  endpoints
 
 	return router
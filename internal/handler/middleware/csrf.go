@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const csrfNonceSize = 16
+
+// CSRF protects cookie authenticated requests with a double-submit token that is
+// bound to the session: the token is stored in a readable cookie, must be echoed
+// back in a header, and its signature covers the current session token.
+type CSRF struct {
+	enabled        bool
+	secret         []byte
+	cookieName     string
+	headerName     string
+	sessionCfg     config.SessionConfig
+	trustedOrigins map[string]struct{}
+	exemptPaths    []string
+	log            *slog.Logger
+}
+
+// NewCSRF creates the CSRF protection.
+// Parameters:
+//   - cfg: CSRF settings; if cfg.Secret is empty a random secret is generated,
+//     which invalidates issued tokens on every restart.
+//   - trustedOrigins: origins accepted in the Origin and Referer headers.
+//   - sessionCfg: session cookie settings, the CSRF cookie shares its attributes.
+//   - log: A *slog.Logger used for logging messages and errors.
+func NewCSRF(cfg config.CSRFConfig, trustedOrigins []string, sessionCfg config.SessionConfig, log *slog.Logger) *CSRF {
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		log.Warn("csrf secret is not configured, using a random one")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+
+	origins := make(map[string]struct{}, len(trustedOrigins))
+	for _, o := range trustedOrigins {
+		origins[strings.TrimSuffix(o, "/")] = struct{}{}
+	}
+
+	return &CSRF{
+		enabled:        cfg.Enabled,
+		secret:         secret,
+		cookieName:     cfg.CookieName,
+		headerName:     cfg.HeaderName,
+		sessionCfg:     sessionCfg,
+		trustedOrigins: origins,
+		exemptPaths:    cfg.ExemptPaths,
+		log:            log,
+	}
+}
+
+// Middleware rejects unsafe requests carrying a session cookie unless they come from a
+// trusted origin and present a valid CSRF token. Requests without the session cookie
+// (bearer tokens, API keys, anonymous requests) are not exposed to CSRF and pass through.
+func (m *CSRF) Middleware() gin.HandlerFunc {
+	const op = "CSRFMiddleware"
+
+	log := m.log.With(slog.String("op", op))
+
+	return func(c *gin.Context) {
+		if !m.enabled || isSafeMethod(c.Request.Method) || m.isExempt(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		session, err := c.Cookie(m.sessionCfg.CookieName)
+		if err != nil || session == "" {
+			c.Next()
+			return
+		}
+
+		if !m.checkOrigin(c.Request) {
+			log.Warn("untrusted origin", slog.String("origin", c.GetHeader("Origin")), slog.String("referer", c.GetHeader("Referer")))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "request origin is not allowed"})
+			return
+		}
+
+		header := c.GetHeader(m.headerName)
+		if header == "" {
+			log.Warn("csrf token header not found")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "csrf token is missing, fetch one from /auth/csrf and send it in the " + m.headerName + " header"})
+			return
+		}
+
+		cookie, err := c.Cookie(m.cookieName)
+		if err != nil || subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) != 1 {
+			log.Warn("csrf token does not match cookie")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "csrf token does not match"})
+			return
+		}
+
+		if !m.verify(header, session) {
+			log.Warn("invalid csrf token")
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "csrf token is invalid or expired, fetch a new one from /auth/csrf"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// IssueToken generates a token bound to the current session, stores it in the CSRF
+// cookie and returns it in the body. Clients must fetch a new token after sign-in.
+func (m *CSRF) IssueToken(c *gin.Context) {
+	const op = "CSRF.IssueToken"
+	log := m.log.With(slog.String("op", op))
+
+	session, _ := c.Cookie(m.sessionCfg.CookieName)
+
+	token, err := m.generate(session)
+	if err != nil {
+		log.Error("failed to generate csrf token", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	// readable by scripts, the token is not a credential without the matching header
+	http.SetCookie(c.Writer, utils.NewCookie(m.sessionCfg, m.cookieName, token, time.Now().Add(m.sessionCfg.RememberLifetime), false))
+	c.JSON(http.StatusOK, gin.H{"csrf_token": token})
+}
+
+func (m *CSRF) generate(session string) (string, error) {
+	nonce := make([]byte, csrfNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	encodedNonce := base64.RawURLEncoding.EncodeToString(nonce)
+	return encodedNonce + "." + m.sign(encodedNonce, session), nil
+}
+
+func (m *CSRF) verify(token, session string) bool {
+	nonce, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(m.sign(nonce, session)))
+}
+
+func (m *CSRF) sign(nonce, session string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(nonce))
+	mac.Write([]byte{'.'})
+	mac.Write([]byte(session))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// checkOrigin verifies the Origin header, falling back to Referer.
+// When neither is sent the decision is left to the token check.
+func (m *CSRF) checkOrigin(r *http.Request) bool {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		_, ok := m.trustedOrigins[origin]
+		return ok
+	}
+
+	if referer := r.Header.Get("Referer"); referer != "" {
+		u, err := url.Parse(referer)
+		if err != nil {
+			return false
+		}
+		_, ok := m.trustedOrigins[u.Scheme+"://"+u.Host]
+		return ok
+	}
+
+	return true
+}
+
+func (m *CSRF) isExempt(path string) bool {
+	for _, p := range m.exemptPaths {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit allows limit requests per window for every client IP on the route.
+// name separates the buckets of different routes sharing the limiter.
+func RateLimit(limiter *ratelimit.Limiter, name string, limit int, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ok, wait := limiter.Allow(name+":"+c.ClientIP(), limit, window); !ok {
+			TooManyRequests(c, wait)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// TooManyRequests aborts with 429 and a Retry-After header.
+func TooManyRequests(c *gin.Context, wait time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, try again later"})
+}
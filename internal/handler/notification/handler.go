@@ -0,0 +1,162 @@
+package notification
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/notification"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+)
+
+type Handler struct {
+	ntfClient *notification.Client
+	log       *slog.Logger
+}
+
+// New creates and returns a new Notification Handler instance
+// Parameters:
+//   - client: A *notification.Client which is a gRPC client for the notification service.
+//   - log: A *slog.Logger used for logging messages and errors.
+//
+// Returns:
+//   - A Handler struct that encapsulates the provided service client and logger.
+func New(client *notification.Client, log *slog.Logger) Handler {
+	return Handler{
+		ntfClient: client,
+		log:       log,
+	}
+}
+
+func (h *Handler) ListNotifications(c *gin.Context) {
+	const op = "NotificationHandler.ListNotifications"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	page, err := utils.GetIntFromQuery(c, "page")
+	if err != nil {
+		log.Warn("failed to get page query parameter", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pageSize, err := utils.GetIntFromQuery(c, "page_size")
+	if err != nil {
+		log.Warn("failed to get page_size query parameter", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.ntfClient.ListNotifications(c, &notification.ListNotificationsRequest{
+		UserID:     userIDFromCtx.(int64),
+		UnreadOnly: c.Query("unread") == "true",
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": domain.MapNotificationObjArrToDomain(res.GetNotifications()),
+		"metadata":      domain.NewPagination(int32(page), int32(pageSize), res.GetTotalCount()),
+	})
+}
+
+func (h *Handler) UnreadCount(c *gin.Context) {
+	const op = "NotificationHandler.UnreadCount"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	res, err := h.ntfClient.UnreadCount(c, &notification.UserRequest{UserID: userIDFromCtx.(int64)})
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread_count": res.Count})
+}
+
+// MarkRead marks the notification as read on PUT and as unread on DELETE.
+func (h *Handler) MarkRead(c *gin.Context) {
+	const op = "NotificationHandler.MarkRead"
+	log := h.log.With(slog.String("op", op))
+
+	notificationID, err := utils.GetIntFromParams(c.Params, "id")
+	if err != nil {
+		log.Warn("failed to get id params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	res, err := h.ntfClient.SetRead(c, &notification.SetReadRequest{
+		UserID:         userIDFromCtx.(int64),
+		NotificationID: notificationID,
+		Read:           c.Request.Method != http.MethodDelete,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("notification not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"notification": domain.NotificationObjectToNotification(res)})
+}
+
+func (h *Handler) MarkAllRead(c *gin.Context) {
+	const op = "NotificationHandler.MarkAllRead"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	res, err := h.ntfClient.MarkAllRead(c, &notification.UserRequest{UserID: userIDFromCtx.(int64)})
+	if err != nil {
+		log.Error("internal", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": res.Updated})
+}
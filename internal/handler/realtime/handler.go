@@ -0,0 +1,181 @@
+package realtime
+
+import (
+	"fmt"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/realtime"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryMillis is the reconnect delay suggested to EventSource clients.
+const retryMillis = 3000
+
+type Handler struct {
+	hub       *realtime.Hub
+	clbClient *club.Client
+	heartbeat time.Duration
+	log       *slog.Logger
+}
+
+// New creates and returns a new Realtime Handler instance
+// Parameters:
+//   - hub: A *realtime.Hub the streams subscribe to.
+//   - clbClient: A *club.Client used to find the clubs the user manages.
+//   - heartbeat: The interval of keep-alive comments sent on idle streams.
+//   - log: A *slog.Logger used for logging messages and errors.
+//
+// Returns:
+//   - A Handler struct that encapsulates the provided hub, client and logger.
+func New(hub *realtime.Hub, clbClient *club.Client, heartbeat time.Duration, log *slog.Logger) Handler {
+	return Handler{
+		hub:       hub,
+		clbClient: clbClient,
+		heartbeat: heartbeat,
+		log:       log,
+	}
+}
+
+// Stream is a server-sent events stream of the caller's events: their own join request
+// decisions, club decisions and notifications, plus the join requests of the clubs where
+// they can manage members. Clients resume with the Last-Event-ID header (sent by
+// EventSource on reconnect) or the last_event_id query parameter. A "reset" event is sent
+// when events were missed, the client should then reload its state.
+func (h *Handler) Stream(c *gin.Context) {
+	const op = "RealtimeHandler.Stream"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	userID := userIDFromCtx.(int64)
+
+	lastEventID, err := lastEventID(c)
+	if err != nil {
+		log.Warn("invalid last event id", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	topics, err := h.topics(c, userID)
+	if err != nil {
+		log.Error("failed to resolve topics", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	sub, replay, complete, err := h.hub.Subscribe(topics, lastEventID)
+	if err != nil {
+		log.Warn("failed to subscribe", logger.Err(err))
+		c.AbortWithStatus(http.StatusServiceUnavailable)
+		return
+	}
+	defer h.hub.Unsubscribe(sub)
+
+	// the stream outlives the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn("failed to clear write deadline", logger.Err(err))
+	}
+
+	w := c.Writer
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	if !complete {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	for _, e := range replay {
+		writeEvent(w, e)
+	}
+	w.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				if sub.Lagged() {
+					log.Warn("slow client dropped", slog.Int64("user_id", userID))
+				}
+				return
+			}
+			writeEvent(w, e)
+			w.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+			w.Flush()
+		}
+	}
+}
+
+// topics returns the user topic, the topics of the clubs where the user can manage
+// members and the attendance topics of the clubs where the user can manage events.
+func (h *Handler) topics(c *gin.Context, userID int64) ([]string, error) {
+	topics := []string{realtime.UserTopic(userID)}
+
+	clubs, err := h.clbClient.GetUserClubs(c, &clubv1.GetUserClubsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	clubIDs := make([]int64, len(clubs.GetClubs()))
+	for i, clb := range clubs.GetClubs() {
+		clubIDs[i] = clb.GetClubId()
+	}
+	members, err := h.clbClient.GetMemberships(c, userID, clubIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, member := range members {
+		if member == nil {
+			continue
+		}
+		access := domain.ClubAccessFromMember(userID, member)
+		if access.Can(domain.PermissionManageMembers) {
+			topics = append(topics, realtime.ClubTopic(clubIDs[i]))
+		}
+		if access.Can(domain.PermissionManageEvents) {
+			topics = append(topics, realtime.AttendanceTopic(clubIDs[i]))
+		}
+	}
+
+	return topics, nil
+}
+
+func lastEventID(c *gin.Context) (uint64, error) {
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("last event id must be a positive integer")
+	}
+	return id, nil
+}
+
+func writeEvent(w gin.ResponseWriter, e realtime.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+}
@@ -0,0 +1,181 @@
+package user
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/middleware"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	activationResendPerEmailLimit  = 3
+	activationResendPerEmailWindow = time.Hour
+
+	activationCodePerEmailLimit  = 5
+	activationCodePerEmailWindow = 15 * time.Minute
+)
+
+var activationCodeRegexp = regexp.MustCompile(`^[0-9]{6}$`)
+
+// ResendActivation sends a new activation email. Like ForgotPassword it answers
+// 202 Accepted for unknown and already activated accounts.
+func (h *Handler) ResendActivation(c *gin.Context) {
+	const op = "UserHandler.ResendActivation"
+	log := h.log.With(slog.String("op", op))
+
+	var input struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if ok, _ := h.limiter.Allow("activation-resend:"+email, activationResendPerEmailLimit, activationResendPerEmailWindow); !ok {
+		log.Warn("activation resend throttled for email")
+		c.Status(http.StatusAccepted)
+		return
+	}
+
+	_, err = h.usrClient.ResendActivation(c, &user.ResendActivationRequest{Email: email})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Info("activation resend requested for unknown email")
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Info("activation resend requested for activated account")
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+			return
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ActivateByCode activates the account with the 6-digit code from the activation email,
+// an alternative to the link for students signing up on mobile.
+func (h *Handler) ActivateByCode(c *gin.Context) {
+	const op = "UserHandler.ActivateByCode"
+	log := h.log.With(slog.String("op", op))
+
+	var input struct {
+		Email string `json:"email" binding:"required,email"`
+		Code  string `json:"code" binding:"required"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	code := strings.TrimSpace(input.Code)
+	if !activationCodeRegexp.MatchString(code) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "code must be 6 digits"})
+		return
+	}
+
+	// a 6-digit code is guessable, attempts are limited per account
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if ok, wait := h.limiter.Allow("activation-code:"+email, activationCodePerEmailLimit, activationCodePerEmailWindow); !ok {
+		log.Warn("activation code attempts throttled for email")
+		middleware.TooManyRequests(c, wait)
+		return
+	}
+
+	_, err = h.usrClient.ActivateUserByCode(c, &user.ActivateUserByCodeRequest{Email: email, Code: code})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("activation code not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "code is invalid or expired"})
+		case status.Code(err) == codes.FailedPrecondition:
+			log.Warn("account already activated", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "account is already activated"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.emitActivated(c, log, email)
+
+	c.Status(http.StatusOK)
+}
+
+// ActivationStatus reports whether the account with the email query parameter is activated.
+// Unknown emails are reported as not activated, so the endpoint cannot tell which
+// emails have an account waiting for activation.
+func (h *Handler) ActivationStatus(c *gin.Context) {
+	const op = "UserHandler.ActivationStatus"
+	log := h.log.With(slog.String("op", op))
+
+	email, ok := c.GetQuery("email")
+	if !ok || email == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "email query parameter must be provided"})
+		return
+	}
+
+	res, err := h.usrClient.GetActivationStatus(c, &user.GetActivationStatusRequest{
+		Email: strings.ToLower(strings.TrimSpace(email)),
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Info("activation status requested for unknown email")
+			c.JSON(http.StatusOK, gin.H{"activated": false})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"activated": res.Activated})
+}
+
+// emitActivated emits user.activated for the account with the email. The event is
+// only emitted if the user can be resolved, consumers rely on the user id.
+func (h *Handler) emitActivated(ctx context.Context, log *slog.Logger, email string) {
+	if h.outbox == nil {
+		return
+	}
+
+	usr, err := h.usrClient.FindUserByEmail(ctx, email)
+	if err != nil {
+		log.Error("failed to resolve activated user, user.activated is not emitted", logger.Err(err))
+		return
+	}
+
+	h.emit(broker.TypeUserActivated, strconv.FormatInt(usr.GetUserId(), 10), map[string]any{
+		"user_id": usr.GetUserId(),
+		"email":   usr.GetEmail(),
+		"method":  "code",
+	})
+}
@@ -1,20 +1,21 @@
 package user
 
 import (
-	"fmt"
+	"errors"
 	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/auth"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jwt"
 	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log/slog"
 	"net/http"
-	"time"
+	"strconv"
 )
 
-const SessionTokenName = "session_token"
-
 func (h *Handler) SignUp(c *gin.Context) {
 	const op = "UserHandler.SignUp"
 
@@ -63,6 +64,18 @@ func (h *Handler) SignUp(c *gin.Context) {
 		return
 	}
 
+	h.emit(broker.TypeUserSignedUp, strconv.FormatInt(res.GetUserId(), 10), map[string]any{
+		"user_id":    res.GetUserId(),
+		"email":      usr.Email,
+		"first_name": usr.FirstName,
+		"last_name":  usr.LastName,
+		"barcode":    usr.Barcode,
+		"major":      usr.Major,
+		"group_name": usr.GroupName,
+		"year":       usr.Year,
+		"method":     "password",
+	})
+
 	c.JSON(http.StatusCreated, gin.H{"userID": res.GetUserId()})
 }
 
@@ -72,8 +85,9 @@ func (h *Handler) SignIn(c *gin.Context) {
 	log := h.log.With(slog.String("op", op))
 
 	usr := struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Email      string `json:"email"`
+		Password   string `json:"password"`
+		RememberMe bool   `json:"remember_me"`
 	}{}
 	err := c.ShouldBindJSON(&usr)
 	if err != nil {
@@ -99,18 +113,7 @@ func (h *Handler) SignIn(c *gin.Context) {
 		return
 	}
 
-	// if https only then secure: true.
-	// todo: deal with cookie domain
-	/*c.SetCookie(SessionTokenName, res.GetSessionToken(), 3600*24, "/", "localhost:3000", false, true)*/
-
-	t := &http.Cookie{
-		Name:     SessionTokenName,
-		Value:    res.GetSessionToken(),
-		Expires:  time.Now().Add(time.Hour * 24),
-		HttpOnly: true,
-		Path:     "/",
-	}
-	http.SetCookie(c.Writer, t)
+	h.startSession(c, log, res.GetSessionToken(), usr.RememberMe)
 	c.JSON(http.StatusOK, gin.H{"user": domain.UserObjectToDomain(res.GetUser())})
 }
 
@@ -119,14 +122,28 @@ func (h *Handler) Logout(c *gin.Context) {
 
 	log := h.log.With(slog.String("op", op))
 
-	cookie, err := c.Cookie(SessionTokenName)
+	sessionToken, fromHeader, err := h.credentials(c)
 	if err != nil {
-		log.Warn("cookie not found", logger.Err(err))
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s cookie not found", SessionTokenName)})
+		log.Warn("credentials not found", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	_, err = h.usrClient.Logout(c, &userv1.LogoutRequest{SessionToken: cookie})
+	// an access token is not a session token, log out the session it was minted for
+	if fromHeader && h.tokens != nil && jwt.LooksLikeJWT(sessionToken) {
+		sessionToken, err = h.tokens.EndSession(c, sessionToken)
+		if err != nil {
+			log.Warn("invalid access token", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired access token"})
+			return
+		}
+		if sessionToken == "" {
+			c.Status(http.StatusOK)
+			return
+		}
+	}
+
+	_, err = h.usrClient.Logout(c, &userv1.LogoutRequest{SessionToken: sessionToken})
 	if err != nil {
 		switch {
 		case status.Code(err) == codes.InvalidArgument:
@@ -138,9 +155,7 @@ func (h *Handler) Logout(c *gin.Context) {
 		}
 		return
 	}
-	// if https only then secure: true.
-	// todo: deal with cookie domain
-	c.SetCookie(SessionTokenName, "", -1, "/", "localhost:3000", false, true)
+	h.clearSessionCookie(c)
 
 	c.Status(http.StatusOK)
 }
@@ -172,5 +187,135 @@ func (h *Handler) Activate(c *gin.Context) {
 		return
 	}
 
+	// the verification token does not identify the user to the gateway, so no
+	// user.activated event is emitted for link activations
 	c.Status(http.StatusOK)
 }
+
+// SignInToken is the sign-in variant for clients that cannot use cookies. The session
+// token is returned in the body and is sent back as "Authorization: Bearer <token>".
+// When JWTs are enabled a short-lived access token and a refresh token are returned as well.
+func (h *Handler) SignInToken(c *gin.Context) {
+	const op = "UserHandler.SignInToken"
+
+	log := h.log.With(slog.String("op", op))
+
+	usr := struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}{}
+	err := c.ShouldBindJSON(&usr)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.usrClient.Login(c, &userv1.LoginRequest{
+		Email:    usr.Email,
+		Password: usr.Password,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	body := gin.H{
+		"session_token": res.GetSessionToken(),
+		"token_type":    "Bearer",
+		"user":          domain.UserObjectToDomain(res.GetUser()),
+	}
+
+	if h.tokens != nil {
+		pair, err := h.tokens.IssuePair(c, res.GetUser().GetUserId(), res.GetSessionToken())
+		if err != nil {
+			log.Error("failed to issue tokens", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		body["tokens"] = pair
+	}
+
+	c.JSON(http.StatusOK, body)
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token pair.
+// The session the refresh token is bound to must still be active.
+func (h *Handler) RefreshToken(c *gin.Context) {
+	const op = "UserHandler.RefreshToken"
+
+	log := h.log.With(slog.String("op", op))
+
+	if h.tokens == nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "token refresh is disabled"})
+		return
+	}
+
+	var input struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Error("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	refresh, err := h.tokens.Refresh(c, input.RefreshToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrRefreshReused) {
+			log.Warn("refresh token reused, session tokens revoked", logger.Err(err))
+		} else {
+			log.Warn("invalid refresh token", logger.Err(err))
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+		return
+	}
+
+	res, err := h.usrClient.Authenticate(c, &userv1.AuthenticateRequest{SessionToken: refresh.SessionToken})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument, status.Code(err) == codes.NotFound:
+			log.Warn("session not found", logger.Err(err))
+			if err := h.tokens.Revoke(c, refresh.SessionID); err != nil {
+				log.Error("failed to revoke refresh tokens", logger.Err(err))
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "session has ended, sign in again"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if res.GetUserId() != refresh.UserID {
+		log.Warn("refresh token subject does not match session")
+		if err := h.tokens.Revoke(c, refresh.SessionID); err != nil {
+			log.Error("failed to revoke refresh tokens", logger.Err(err))
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+		return
+	}
+
+	pair, err := h.tokens.Rotate(c, refresh)
+	if errors.Is(err, auth.ErrRefreshReused) {
+		log.Warn("refresh token used concurrently, session tokens revoked", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired refresh token"})
+		return
+	}
+	if err != nil {
+		log.Error("failed to issue tokens", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": pair})
+}
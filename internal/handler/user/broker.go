@@ -0,0 +1,29 @@
+package user
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"log/slog"
+	"time"
+)
+
+// emitTimeout bounds storing a single event in the outbox.
+const emitTimeout = 10 * time.Second
+
+// emit stores a domain event about the subject (an entity ID, may be empty) in the outbox,
+// it is published to the broker in the background. Failures are only logged.
+func (h *Handler) emit(eventType, subject string, data map[string]any) {
+	if h.outbox == nil {
+		return
+	}
+
+	const op = "UserHandler.emit"
+	log := h.log.With(slog.String("op", op), slog.String("type", eventType))
+
+	ctx, cancel := context.WithTimeout(context.Background(), emitTimeout)
+	defer cancel()
+
+	if err := h.outbox.Add(ctx, eventType, subject, data); err != nil {
+		log.Error("failed to store event", logger.Err(err))
+	}
+}
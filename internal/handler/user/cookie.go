@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// expiryCookieSuffix names the companion cookie that remembers when the session cookie
+// expires and whether it is a "remember me" session, browsers do not send cookie expiry back.
+// Its value is signed and bound to the session token, so the client cannot change it.
+const expiryCookieSuffix = "_exp"
+
+// setSessionCookie sets the session cookie and its companion expiry cookie.
+func (h *Handler) setSessionCookie(c *gin.Context, token string, remember bool, expires time.Time) {
+	value := fmt.Sprintf("%d.%d", expires.Unix(), rememberFlag(remember))
+	value += "." + h.signExpiry(token, value)
+
+	http.SetCookie(c.Writer, utils.NewCookie(h.sessionCfg, h.sessionCfg.CookieName, token, expires, true))
+	http.SetCookie(c.Writer, utils.NewCookie(h.sessionCfg, h.sessionCfg.CookieName+expiryCookieSuffix, value, expires, true))
+}
+
+// startSession sets the cookies of a new session. Remember-me sessions are extended
+// in the user service first, the cookie never outlives the session behind it.
+func (h *Handler) startSession(c *gin.Context, log *slog.Logger, token string, remember bool) {
+	expires := time.Now().Add(h.sessionCfg.Lifetime)
+	if remember {
+		extended, err := h.extendSession(c, token, h.sessionCfg.RememberLifetime)
+		if err != nil {
+			log.Error("failed to extend session, falling back to the default lifetime", logger.Err(err))
+			remember = false
+		} else {
+			expires = extended
+		}
+	}
+
+	h.setSessionCookie(c, token, remember, expires)
+}
+
+// clearSessionCookie removes the cookies set by setSessionCookie using the same attributes.
+func (h *Handler) clearSessionCookie(c *gin.Context) {
+	http.SetCookie(c.Writer, utils.NewCookie(h.sessionCfg, h.sessionCfg.CookieName, "", time.Time{}, true))
+	http.SetCookie(c.Writer, utils.NewCookie(h.sessionCfg, h.sessionCfg.CookieName+expiryCookieSuffix, "", time.Time{}, true))
+}
+
+// renewSessionCookie extends the session and its cookie when it is close to expiry.
+// Cookies without a valid expiry cookie are left alone, they expire on their own.
+func (h *Handler) renewSessionCookie(c *gin.Context, log *slog.Logger, token string) {
+	expires, remember, ok := h.sessionCookieExpiry(c, token)
+	if !ok || time.Until(expires) > h.sessionCfg.RenewBefore {
+		return
+	}
+
+	lifetime := h.sessionCfg.Lifetime
+	if remember {
+		lifetime = h.sessionCfg.RememberLifetime
+	}
+	expires, err := h.extendSession(c, token, lifetime)
+	if err != nil {
+		log.Warn("failed to extend session", logger.Err(err))
+		return
+	}
+
+	h.setSessionCookie(c, token, remember, expires)
+}
+
+func (h *Handler) extendSession(ctx context.Context, token string, lifetime time.Duration) (time.Time, error) {
+	res, err := h.usrClient.ExtendSession(ctx, &user.ExtendSessionRequest{
+		SessionToken: token,
+		TTLSeconds:   int64(lifetime.Seconds()),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	expires := time.Now().Add(lifetime)
+	if at := res.GetExpiresAt(); !at.IsZero() && at.Before(expires) {
+		expires = at
+	}
+	return expires, nil
+}
+
+// sessionCookieExpiry reads the expiry cookie, ok is false if it is missing or its
+// signature does not match the session token.
+func (h *Handler) sessionCookieExpiry(c *gin.Context, token string) (expires time.Time, remember bool, ok bool) {
+	value, err := c.Cookie(h.sessionCfg.CookieName + expiryCookieSuffix)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+
+	i := strings.LastIndexByte(value, '.')
+	if i < 0 || !hmac.Equal([]byte(value[i+1:]), []byte(h.signExpiry(token, value[:i]))) {
+		return time.Time{}, false, false
+	}
+
+	unix, flag, _ := strings.Cut(value[:i], ".")
+	sec, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+
+	return time.Unix(sec, 0), flag == "1", true
+}
+
+func (h *Handler) signExpiry(token, value string) string {
+	mac := hmac.New(sha256.New, h.sessionKey)
+	mac.Write([]byte(token))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func rememberFlag(remember bool) int {
+	if remember {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+package user
+
+import (
+	"context"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	revokeSessionsAttempts = 3
+	revokeSessionsBackoff  = 200 * time.Millisecond
+)
+
+// RequestEmailChange starts an email change for the authenticated user. The current
+// password is required and the new address must be verified before it is applied.
+func (h *Handler) RequestEmailChange(c *gin.Context) {
+	const op = "UserHandler.RequestEmailChange"
+	log := h.log.With(slog.String("op", op))
+
+	userID, err := utils.GetIntFromParams(c.Params, "id")
+	if err != nil {
+		log.Warn("failed to get id params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	if userID != userIDFromCtx.(int64) {
+		log.Warn("not account owner")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	var input struct {
+		Password string `json:"password" binding:"required"`
+		NewEmail string `json:"new_email" binding:"required,email"`
+	}
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err = h.usrClient.RequestEmailChange(c, &user.RequestEmailChangeRequest{
+		UserID:   userID,
+		Password: input.Password,
+		NewEmail: strings.ToLower(strings.TrimSpace(input.NewEmail)),
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.PermissionDenied, status.Code(err) == codes.Unauthenticated:
+			log.Warn("wrong password", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "password is incorrect"})
+		case status.Code(err) == codes.AlreadyExists:
+			log.Warn("email already taken", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("user not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ConfirmEmailChange applies the email change with the token sent to the new address
+// and ends every other session of the user. The session making the request, if any, is kept.
+func (h *Handler) ConfirmEmailChange(c *gin.Context) {
+	const op = "UserHandler.ConfirmEmailChange"
+	log := h.log.With(slog.String("op", op))
+
+	var input struct {
+		Token string `json:"token" binding:"required"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.usrClient.ConfirmEmailChange(c, &user.ConfirmEmailChangeRequest{Token: input.Token})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("email change token not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "token is invalid or expired"})
+		case status.Code(err) == codes.AlreadyExists:
+			log.Warn("email already taken", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// the user service keeps the current session only if it belongs to the user
+	currentSession, _, _ := h.credentials(c)
+
+	if err := h.revokeSessions(c, res.GetUserId(), currentSession); err != nil {
+		// the email is already changed, retrying the confirmation would not help
+		log.Error("email changed but other sessions were not revoked", logger.Err(err), slog.Int64("user_id", res.GetUserId()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "email was changed but other sessions could not be signed out, sign out of other devices manually",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": domain.UserObjectToDomain(res)})
+}
+
+// revokeSessions revokes every session of the user except the given one, transient
+// failures are retried.
+func (h *Handler) revokeSessions(ctx context.Context, userID int64, except string) error {
+	var err error
+	for attempt := 1; attempt <= revokeSessionsAttempts; attempt++ {
+		_, err = h.usrClient.RevokeSessions(ctx, &user.RevokeSessionsRequest{
+			UserID:             userID,
+			ExceptSessionToken: except,
+		})
+		if err == nil || status.Code(err) == codes.InvalidArgument || attempt == revokeSessionsAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * revokeSessionsBackoff):
+		}
+	}
+	return err
+}
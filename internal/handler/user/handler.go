@@ -1,46 +1,117 @@
 package user
 
 import (
+	"crypto/rand"
 	"fmt"
 	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/apikey"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/auth"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/oidc"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jwt"
 	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/ratelimit"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log/slog"
 	"net/http"
+	"strings"
 )
 
 type Handler struct {
-	usrClient *user.Client
-	log       *slog.Logger
+	usrClient  *user.Client
+	clbClient  *club.Client
+	sessionCfg config.SessionConfig
+	sessionKey []byte
+	tokens     *auth.TokenIssuer
+	oidc       *oidc.Provider
+	limiter    *ratelimit.Limiter
+	outbox     *broker.Outbox
+	log        *slog.Logger
 }
 
 // New creates and returns a new User Handler instance
 // Parameters:
 //   - client: A *user.Client which is a gRPC client for the user service.
+//   - clubClient: A *club.Client which is a gRPC client for the club service.
+//   - sessionCfg: A config.SessionConfig with the session cookie attributes; if its Secret
+//     is empty a random one is generated, which stops sessions from renewing after a restart.
+//   - tokens: A *auth.TokenIssuer for signed access tokens, nil when JWTs are disabled.
+//   - oidcProvider: A *oidc.Provider for single sign-on, nil when it is disabled.
+//   - limiter: A *ratelimit.Limiter throttling account recovery emails.
+//   - outbox: A *broker.Outbox publishing sign-up and activation events.
 //   - log: A *slog.Logger used for logging messages and errors.
 //
 // Returns:
-//   - A Handler struct that encapsulates the provided user service client and logger.
-func New(client *user.Client, log *slog.Logger) Handler {
+//   - A Handler struct that encapsulates the provided service clients and logger.
+func New(
+	client *user.Client,
+	clubClient *club.Client,
+	sessionCfg config.SessionConfig,
+	tokens *auth.TokenIssuer,
+	oidcProvider *oidc.Provider,
+	limiter *ratelimit.Limiter,
+	outbox *broker.Outbox,
+	log *slog.Logger,
+) Handler {
+	sessionKey := []byte(sessionCfg.Secret)
+	if len(sessionKey) == 0 {
+		log.Warn("session secret is not configured, using a random one")
+		sessionKey = make([]byte, 32)
+		if _, err := rand.Read(sessionKey); err != nil {
+			panic(err)
+		}
+	}
+
 	return Handler{
-		usrClient: client,
-		log:       log,
+		usrClient:  client,
+		clbClient:  clubClient,
+		sessionCfg: sessionCfg,
+		sessionKey: sessionKey,
+		tokens:     tokens,
+		oidc:       oidcProvider,
+		limiter:    limiter,
+		outbox:     outbox,
+		log:        log,
 	}
 }
 
+// SessionAuthMiddleware authenticates the request by the session cookie or by an
+// "Authorization: Bearer <token>" header. A bearer token is either the session token
+// returned by /auth/token or, when enabled, a signed access token minted by the gateway.
+// Requests already authenticated by an API key pass through.
 func (h *Handler) SessionAuthMiddleware() gin.HandlerFunc {
 	const op = "SessionAuthMiddleware"
 
 	log := h.log.With(slog.String("op", op))
 
 	return func(c *gin.Context) {
-		sessionToken, err := c.Cookie(SessionTokenName)
+		if _, ok := c.Get(apikey.ContextKey); ok {
+			c.Next()
+			return
+		}
+
+		sessionToken, fromHeader, err := h.credentials(c)
 		if err != nil {
-			log.Warn("cookie not found", logger.Err(err))
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s cookie not found", SessionTokenName)})
+			log.Warn("credentials not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if fromHeader && h.tokens != nil && jwt.LooksLikeJWT(sessionToken) {
+			userID, err := h.tokens.ParseAccessToken(sessionToken)
+			if err != nil {
+				log.Warn("invalid access token", logger.Err(err))
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired access token"})
+				return
+			}
+
+			c.Set("userID", userID)
+			c.Next()
 			return
 		}
 
@@ -63,11 +134,34 @@ func (h *Handler) SessionAuthMiddleware() gin.HandlerFunc {
 		}
 
 		c.Set("userID", res.GetUserId())
+		c.Set("sessionToken", sessionToken)
+		if !fromHeader {
+			h.renewSessionCookie(c, log, sessionToken)
+		}
 
 		c.Next()
 	}
 }
 
+// credentials returns the bearer token from the Authorization header or, if there is
+// none, the session cookie. fromHeader reports which of the two was used.
+func (h *Handler) credentials(c *gin.Context) (token string, fromHeader bool, err error) {
+	if header := c.GetHeader("Authorization"); header != "" {
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return "", true, fmt.Errorf("authorization header must be in the form \"Bearer <token>\"")
+		}
+		return token, true, nil
+	}
+
+	cookie, err := c.Cookie(h.sessionCfg.CookieName)
+	if err != nil {
+		return "", false, fmt.Errorf("%s cookie not found", h.sessionCfg.CookieName)
+	}
+
+	return cookie, false, nil
+}
+
 func (h *Handler) RoleAuthMiddleware(roles []userv1.Role) gin.HandlerFunc {
 	const op = "RoleAuthMiddleware"
 
@@ -0,0 +1,85 @@
+package user
+
+import (
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/club"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+)
+
+// Me returns the authenticated user together with the clubs they belong to,
+// their roles and effective permissions in each club and their pending join requests.
+func (h *Handler) Me(c *gin.Context) {
+	const op = "UserHandler.Me"
+	log := h.log.With(slog.String("op", op))
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	userID := userIDFromCtx.(int64)
+
+	usr, err := h.usrClient.GetUser(c, &userv1.GetUserRequest{UserId: userID})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Warn("user not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	clubsRes, err := h.clbClient.GetUserClubs(c, &clubv1.GetUserClubsRequest{UserId: userID})
+	if err != nil && status.Code(err) != codes.NotFound {
+		log.Error("failed to get user clubs", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	clubIDs := make([]int64, len(clubsRes.GetClubs()))
+	for i, clubObj := range clubsRes.GetClubs() {
+		clubIDs[i] = clubObj.GetClubId()
+	}
+	members, err := h.clbClient.GetMemberships(c, userID, clubIDs)
+	if err != nil {
+		log.Error("failed to get club memberships", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	memberships := make([]domain.Membership, 0, len(members))
+	for i, member := range members {
+		// a nil member means the membership ended between the two calls
+		if member == nil {
+			continue
+		}
+		memberships = append(memberships, domain.MemberObjectToMembership(domain.ClubObjectToClub(clubsRes.GetClubs()[i]), member))
+	}
+
+	joinRes, err := h.clbClient.ListUserJoinRequests(c, &club.ListUserJoinRequestsRequest{UserID: userID})
+	if err != nil && status.Code(err) != codes.NotFound {
+		log.Error("failed to list user join requests", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	user := domain.UserObjectToDomain(usr)
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":          user,
+		"role":          user.Role,
+		"clubs":         memberships,
+		"join_requests": domain.MapJoinRequestObjArrToDomain(joinRes.GetRequests()),
+	})
+}
@@ -0,0 +1,189 @@
+package user
+
+import (
+	"errors"
+	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/broker"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/oidc"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const oidcStateCookieName = "oidc_state"
+
+// OIDCLogin starts the university single sign-on: it stores the state, nonce and PKCE
+// verifier in a signed cookie and redirects the browser to the identity provider.
+func (h *Handler) OIDCLogin(c *gin.Context) {
+	const op = "UserHandler.OIDCLogin"
+	log := h.log.With(slog.String("op", op))
+
+	if h.oidc == nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "single sign-on is disabled"})
+		return
+	}
+
+	state, err := oidc.NewLoginState()
+	if err != nil {
+		log.Error("failed to generate login state", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	authURL, err := h.oidc.AuthCodeURL(c, state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		log.Error("failed to build authorization url", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"error": "identity provider is unavailable"})
+		return
+	}
+
+	sealed, err := h.oidc.SealState(state)
+	if err != nil {
+		log.Error("failed to seal login state", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	cookie := utils.NewCookie(h.sessionCfg, oidcStateCookieName, sealed, time.Now().Add(oidc.StateTTL), true)
+	// the callback is a cross-site top-level navigation, strict cookies would not be sent
+	cookie.SameSite = http.SameSiteLaxMode
+	http.SetCookie(c.Writer, cookie)
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback completes the single sign-on. On the first login the account is
+// registered from the ID token claims, then the usual session cookie is issued.
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	const op = "UserHandler.OIDCCallback"
+	log := h.log.With(slog.String("op", op))
+
+	if h.oidc == nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "single sign-on is disabled"})
+		return
+	}
+
+	if idpErr := c.Query("error"); idpErr != "" {
+		log.Warn("identity provider returned an error", slog.String("error", idpErr), slog.String("description", c.Query("error_description")))
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "sign-in was cancelled or denied by the identity provider"})
+		return
+	}
+
+	sealed, err := c.Cookie(oidcStateCookieName)
+	if err != nil {
+		log.Warn("state cookie not found", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "login state not found, start the sign-in again"})
+		return
+	}
+	http.SetCookie(c.Writer, utils.NewCookie(h.sessionCfg, oidcStateCookieName, "", time.Time{}, true))
+
+	state, err := h.oidc.OpenState(sealed)
+	if err != nil || state.State != c.Query("state") {
+		log.Warn("invalid login state", slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid or expired login state, start the sign-in again"})
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "code was not provided"})
+		return
+	}
+
+	claims, err := h.oidc.Exchange(c, code, state.Verifier, state.Nonce)
+	if err != nil {
+		switch {
+		case errors.Is(err, oidc.ErrInvalidToken):
+			log.Warn("invalid id token", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid id token"})
+		default:
+			log.Error("code exchange failed", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{"error": "identity provider is unavailable"})
+		}
+		return
+	}
+
+	if claims.Email == "" {
+		log.Warn("email claim is missing", slog.String("subject", claims.Subject))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "identity provider did not return an email"})
+		return
+	}
+	// ExternalLogin links existing accounts by email, an unverified email could take one over
+	if !claims.EmailIsVerified() {
+		log.Warn("email is not verified", slog.String("subject", claims.Subject))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "email is not verified by the identity provider"})
+		return
+	}
+
+	externalLogin := &user.ExternalLoginRequest{
+		Provider: h.oidc.Name(),
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+
+	res, err := h.usrClient.ExternalLogin(c, externalLogin)
+	if status.Code(err) == codes.NotFound {
+		log.Info("first single sign-on login, registering user", slog.String("subject", claims.Subject))
+
+		if err = h.registerFromClaims(c, claims); err == nil {
+			res, err = h.usrClient.ExternalLogin(c, externalLogin)
+		}
+	}
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.AlreadyExists:
+			log.Warn("user already exists", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.startSession(c, log, res.GetSessionToken(), false)
+	c.Redirect(http.StatusFound, h.oidc.Config().PostLoginRedirect)
+}
+
+// registerFromClaims maps the ID token claims to a RegisterRequest. The account gets a
+// random password, the user signs in through the identity provider or resets it.
+func (h *Handler) registerFromClaims(c *gin.Context, claims *oidc.Claims) error {
+	password, err := oidc.RandomString()
+	if err != nil {
+		return err
+	}
+
+	cfg := h.oidc.Config()
+	req := &userv1.RegisterRequest{
+		Email:     claims.Email,
+		Password:  password,
+		FirstName: claims.String(cfg.FirstNameClaim),
+		LastName:  claims.String(cfg.LastNameClaim),
+		Barcode:   claims.String(cfg.BarcodeClaim),
+	}
+	res, err := h.usrClient.Register(c, req)
+	if err != nil {
+		return err
+	}
+
+	h.emit(broker.TypeUserSignedUp, strconv.FormatInt(res.GetUserId(), 10), map[string]any{
+		"user_id":    res.GetUserId(),
+		"email":      req.Email,
+		"first_name": req.FirstName,
+		"last_name":  req.LastName,
+		"barcode":    req.Barcode,
+		"method":     "sso",
+		"provider":   h.oidc.Name(),
+	})
+	return nil
+}
@@ -0,0 +1,89 @@
+package user
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/oidc"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/oidc/oidctest"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// oidcCallback runs /auth/oidc/login, approves the login at the mock provider with
+// the claims and returns the response of the callback.
+func oidcCallback(t *testing.T, claims map[string]any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	idp, err := oidctest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(idp.Close)
+
+	provider := oidc.NewProvider(config.OIDCConfig{
+		ProviderName: "test",
+		IssuerURL:    idp.Issuer(),
+		ClientID:     "gateway",
+		RedirectURL:  "http://localhost/auth/oidc/callback",
+		Scopes:       []string{"openid", "email"},
+	})
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// the client is not reached, every case is rejected before ExternalLogin
+	h := New(nil, nil, config.SessionConfig{CookieName: "session_token", Path: "/"}, nil, provider, nil, nil, log)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/auth/oidc/login", h.OIDCLogin)
+	router.GET("/auth/oidc/callback", h.OIDCCallback)
+
+	loginRes := httptest.NewRecorder()
+	router.ServeHTTP(loginRes, httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil))
+	if loginRes.Code != http.StatusFound {
+		t.Fatalf("login status = %d, body = %s", loginRes.Code, loginRes.Body)
+	}
+
+	authURL := loginRes.Header().Get("Location")
+	code, err := idp.Authorize(authURL, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := url.Values{"code": {code}, "state": {u.Query().Get("state")}}
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?"+q.Encode(), nil)
+	for _, cookie := range loginRes.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	return res
+}
+
+func TestOIDCCallbackRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   int
+	}{
+		{"email_verified missing", map[string]any{"email": "student@university.kz"}, http.StatusForbidden},
+		{"email_verified false", map[string]any{"email": "student@university.kz", "email_verified": false}, http.StatusForbidden},
+		{"email missing", map[string]any{"email_verified": true}, http.StatusBadRequest},
+		{"nonce mismatch", map[string]any{"email": "student@university.kz", "email_verified": true, "nonce": "other"}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := oidcCallback(t, tt.claims)
+			if res.Code != tt.want {
+				t.Fatalf("callback status = %d, want %d, body = %s", res.Code, tt.want, res.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package user
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/clients/user"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	passwordForgotPerEmailLimit  = 3
+	passwordForgotPerEmailWindow = time.Hour
+)
+
+// ForgotPassword requests a password reset email. It always answers 202 Accepted,
+// whether the account exists or the email is throttled, so accounts cannot be enumerated.
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	const op = "UserHandler.ForgotPassword"
+	log := h.log.With(slog.String("op", op))
+
+	var input struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if ok, _ := h.limiter.Allow("password-forgot:"+email, passwordForgotPerEmailLimit, passwordForgotPerEmailWindow); !ok {
+		log.Warn("password reset throttled for email")
+		c.Status(http.StatusAccepted)
+		return
+	}
+
+	_, err = h.usrClient.RequestPasswordReset(c, &user.RequestPasswordResetRequest{Email: email})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			log.Info("password reset requested for unknown email")
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+			return
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPassword sets a new password with the token from the reset email.
+func (h *Handler) ResetPassword(c *gin.Context) {
+	const op = "UserHandler.ResetPassword"
+	log := h.log.With(slog.String("op", op))
+
+	var input struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required"`
+	}
+	err := c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err = h.usrClient.ResetPassword(c, &user.ResetPasswordRequest{
+		Token:       input.Token,
+		NewPassword: input.NewPassword,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("reset token not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "reset token is invalid or expired"})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ChangePassword changes the password of the authenticated user, the current password is required.
+func (h *Handler) ChangePassword(c *gin.Context) {
+	const op = "UserHandler.ChangePassword"
+	log := h.log.With(slog.String("op", op))
+
+	userID, err := utils.GetIntFromParams(c.Params, "id")
+	if err != nil {
+		log.Warn("failed to get id params", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDFromCtx, ok := c.Get("userID")
+	if !ok {
+		log.Warn("userID not found")
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	if userID != userIDFromCtx.(int64) {
+		log.Warn("not account owner")
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	var input struct {
+		CurrentPassword string `json:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" binding:"required"`
+	}
+	err = c.ShouldBindJSON(&input)
+	if err != nil {
+		log.Warn("decoding err", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err = h.usrClient.ChangePassword(c, &user.ChangePasswordRequest{
+		UserID:          userID,
+		CurrentPassword: input.CurrentPassword,
+		NewPassword:     input.NewPassword,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			log.Warn("invalid arguments", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case status.Code(err) == codes.PermissionDenied, status.Code(err) == codes.Unauthenticated:
+			log.Warn("wrong current password", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "current password is incorrect"})
+		case status.Code(err) == codes.NotFound:
+			log.Warn("user not found", logger.Err(err))
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+		default:
+			log.Error("internal", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"bytes"
+	clubv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/club"
 	userv1 "github.com/ARUMANDESU/uniclubs-protos/gen/go/user"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/domain"
 	"github.com/ARUMANDESU/university-clubs-backend/internal/handler/utils"
@@ -15,6 +16,14 @@ import (
 	"net/http"
 )
 
+// expandClubs inlines the clubs of the user in GET /user/:id.
+const expandClubs = "clubs"
+
+var (
+	userFields     = utils.JSONFields(domain.User{})
+	userExpansions = []string{expandClubs}
+)
+
 func (h *Handler) GetUser(c *gin.Context) {
 	const op = "UserHandler.GetUser"
 	log := h.log.With(slog.String("op", op))
@@ -26,6 +35,20 @@ func (h *Handler) GetUser(c *gin.Context) {
 		return
 	}
 
+	fields, err := utils.GetFieldsFromQuery(c, userFields)
+	if err != nil {
+		log.Warn("invalid fields", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expand, err := utils.GetExpandFromQuery(c, userExpansions)
+	if err != nil {
+		log.Warn("invalid expand", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	res, err := h.usrClient.GetUser(c, &userv1.GetUserRequest{UserId: userID})
 	if err != nil {
 		switch {
@@ -42,7 +65,27 @@ func (h *Handler) GetUser(c *gin.Context) {
 		return
 	}
 
-	user := domain.UserObjectToDomain(res)
+	user, err := utils.SelectFields(domain.UserObjectToDomain(res), fields)
+	if err != nil {
+		log.Error("failed to select fields", logger.Err(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	if expand.Has(expandClubs) {
+		clubs, err := h.clbClient.GetUserClubs(c, &clubv1.GetUserClubsRequest{UserId: userID})
+		if err != nil && status.Code(err) != codes.NotFound {
+			log.Error("failed to get user clubs", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		items := make([]*domain.Club, len(clubs.GetClubs()))
+		for i, clb := range clubs.GetClubs() {
+			items[i] = domain.ClubObjectToClub(clb)
+		}
+		user["clubs"] = items
+	}
 
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
@@ -179,6 +222,21 @@ func (h *Handler) SearchUsers(c *gin.Context) {
 	log := h.log.With(slog.String("op", op))
 
 	query := c.Query("query")
+
+	fields, err := utils.GetFieldsFromQuery(c, userFields)
+	if err != nil {
+		log.Warn("invalid fields", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// search results support no expansions, expand= is still validated
+	if _, err := utils.GetExpandFromQuery(c, nil); err != nil {
+		log.Warn("invalid expand", logger.Err(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	page, err := utils.GetIntFromQuery(c, "page")
 	if err != nil {
 		log.Warn("failed to get page query parameter", logger.Err(err))
@@ -209,9 +267,17 @@ func (h *Handler) SearchUsers(c *gin.Context) {
 		}
 		return
 	}
-	users := domain.MapUserObjectArrToDomain(res.Users)
+	users := make([]map[string]any, len(res.GetUsers()))
+	for i, usr := range res.GetUsers() {
+		users[i], err = utils.SelectFields(domain.UserObjectToDomain(usr), fields)
+		if err != nil {
+			log.Error("failed to select fields", logger.Err(err))
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	}
 
-	c.JSON(http.StatusOK, gin.H{"users": users, "metadata": res.Metadata})
+	c.JSON(http.StatusOK, gin.H{"users": users, "metadata": domain.UserPaginationToDomain(res.GetMetadata())})
 }
 
 func (h *Handler) UpdateAvatar(c *gin.Context) {
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NewCookie builds a cookie with the domain, path, Secure and SameSite attributes from cfg,
+// so every cookie the gateway sets can be cleared with the same attributes.
+// A zero expires clears the cookie.
+func NewCookie(cfg config.SessionConfig, name, value string, expires time.Time, httpOnly bool) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Domain:   cfg.Domain,
+		Path:     cfg.Path,
+		Secure:   cfg.Secure,
+		HttpOnly: httpOnly,
+		SameSite: ParseSameSite(cfg.SameSite),
+	}
+
+	if expires.IsZero() {
+		cookie.Expires = time.Unix(0, 0)
+		cookie.MaxAge = -1
+		return cookie
+	}
+
+	cookie.Expires = expires
+	cookie.MaxAge = int(time.Until(expires).Seconds())
+	return cookie
+}
+
+func ParseSameSite(s string) http.SameSite {
+	switch strings.ToLower(s) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	case "lax":
+		return http.SameSiteLaxMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
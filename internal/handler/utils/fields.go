@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Fieldset is the set of response fields requested with "fields=", a nil Fieldset selects every field.
+type Fieldset map[string]struct{}
+
+// Has reports whether the field is selected.
+func (f Fieldset) Has(field string) bool {
+	if f == nil {
+		return true
+	}
+	_, ok := f[field]
+	return ok
+}
+
+// Expansions is the set of related resources requested with "expand=".
+type Expansions map[string]struct{}
+
+// Has reports whether the related resource should be inlined.
+func (e Expansions) Has(name string) bool {
+	_, ok := e[name]
+	return ok
+}
+
+// GetFieldsFromQuery reads the "fields" query parameter, repeated or comma-separated.
+// It returns a nil Fieldset when the parameter is missing and an error naming the
+// allowed fields when an unknown field is requested.
+func GetFieldsFromQuery(c *gin.Context, allowed []string) (Fieldset, error) {
+	values := GetStringsFromQuery(c, "fields")
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	fields := make(Fieldset, len(values))
+	for _, v := range values {
+		if !slices.Contains(allowed, v) {
+			return nil, fmt.Errorf("unknown field %q, allowed fields: %s", v, strings.Join(allowed, ", "))
+		}
+		fields[v] = struct{}{}
+	}
+
+	return fields, nil
+}
+
+// GetExpandFromQuery reads the "expand" query parameter, repeated or comma-separated,
+// and rejects expansions the endpoint does not support.
+func GetExpandFromQuery(c *gin.Context, allowed []string) (Expansions, error) {
+	values := GetStringsFromQuery(c, "expand")
+
+	expand := make(Expansions, len(values))
+	for _, v := range values {
+		if !slices.Contains(allowed, v) {
+			if len(allowed) == 0 {
+				return nil, fmt.Errorf("unknown expansion %q, this endpoint supports no expansions", v)
+			}
+			return nil, fmt.Errorf("unknown expansion %q, allowed expansions: %s", v, strings.Join(allowed, ", "))
+		}
+		expand[v] = struct{}{}
+	}
+
+	return expand, nil
+}
+
+// JSONFields returns the sorted JSON names of the exported fields of the struct v.
+func JSONFields(v any) []string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+// SelectFields encodes v as a JSON object and keeps the fields in the set. The result
+// can be extended with expanded resources before it is written.
+func SelectFields(v any, fields Fieldset) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]any, len(object))
+	for name, value := range object {
+		if fields.Has(name) {
+			res[name] = value
+		}
+	}
+
+	return res, nil
+}
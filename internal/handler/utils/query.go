@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func GetIntFromParams(c gin.Params, param string) (int64, error) {
@@ -32,3 +34,60 @@ func GetIntFromQuery(c *gin.Context, query string) (int, error) {
 
 	return res, nil
 }
+
+// GetTimeFromQuery parses an optional RFC 3339 timestamp or 2006-01-02 date query parameter.
+// A missing parameter yields the zero time.
+func GetTimeFromQuery(c *gin.Context, query string) (time.Time, error) {
+	q := c.Query(query)
+	if q == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, q); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, q)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s query parameter must be an RFC 3339 timestamp or a YYYY-MM-DD date", query)
+	}
+
+	return t, nil
+}
+
+// GetStringsFromQuery returns the values of an array query parameter given either
+// repeated ("?tag=a&tag=b") or comma-separated ("?tag=a,b"), or both. Values are
+// trimmed, empty and duplicate values are dropped.
+func GetStringsFromQuery(c *gin.Context, query string) []string {
+	var values []string
+	seen := make(map[string]struct{})
+	for _, q := range c.QueryArray(query) {
+		for _, v := range strings.Split(q, ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// GetBoolFromQuery parses an optional boolean query parameter, a missing parameter yields nil.
+func GetBoolFromQuery(c *gin.Context, query string) (*bool, error) {
+	q := c.Query(query)
+	if q == "" {
+		return nil, nil
+	}
+
+	b, err := strconv.ParseBool(q)
+	if err != nil {
+		return nil, fmt.Errorf("%s query parameter must be a boolean", query)
+	}
+
+	return &b, nil
+}
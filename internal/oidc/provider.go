@@ -0,0 +1,349 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jwt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	clockSkew = time.Minute
+	// keyRefetchInterval is the minimum time between two key set fetches, tokens with
+	// unknown kids cannot make the gateway hammer the provider.
+	keyRefetchInterval = 30 * time.Second
+)
+
+var (
+	ErrDiscovery    = errors.New("oidc discovery failed")
+	ErrExchange     = errors.New("oidc code exchange failed")
+	ErrInvalidToken = errors.New("invalid id token")
+	ErrUnknownKey   = errors.New("unknown signing key")
+)
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Claims are the ID token claims, Raw holds every claim for provider specific mapping.
+type Claims struct {
+	jwt.RegisteredClaims
+	Nonce         string         `json:"nonce"`
+	Email         string         `json:"email"`
+	EmailVerified *bool          `json:"email_verified"`
+	Raw           map[string]any `json:"-"`
+}
+
+// EmailIsVerified reports whether the provider vouches for the email. A missing
+// email_verified claim counts as unverified.
+func (c Claims) EmailIsVerified() bool {
+	return c.EmailVerified != nil && *c.EmailVerified
+}
+
+// String returns the claim as a string, numbers are formatted without exponent.
+func (c Claims) String(name string) string {
+	switch v := c.Raw[name].(type) {
+	case string:
+		return v
+	case float64:
+		return big.NewFloat(v).Text('f', -1)
+	default:
+		return ""
+	}
+}
+
+// Provider implements the authorization code flow with PKCE against an OpenID Connect
+// provider. Discovery and signing keys are fetched lazily and cached.
+type Provider struct {
+	cfg         config.OIDCConfig
+	client      *http.Client
+	stateSecret []byte
+
+	// mu guards the cached metadata and keys, it is never held during a fetch.
+	mu              sync.Mutex
+	meta            *discovery
+	keys            map[string]*rsa.PublicKey
+	keysFetchedAt   time.Time
+	refetchInterval time.Duration
+}
+
+func NewProvider(cfg config.OIDCConfig) *Provider {
+	stateSecret := []byte(cfg.StateSecret)
+	if len(stateSecret) == 0 {
+		stateSecret = randomSecret()
+	}
+
+	return &Provider{
+		cfg:             cfg,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		stateSecret:     stateSecret,
+		refetchInterval: keyRefetchInterval,
+	}
+}
+
+// Config returns the provider configuration, used for claim mapping and redirects.
+func (p *Provider) Config() config.OIDCConfig {
+	return p.cfg
+}
+
+// Name returns the provider name used to link external identities.
+func (p *Provider) Name() string {
+	return p.cfg.ProviderName
+}
+
+// AuthCodeURL returns the provider's authorization URL for the given state, nonce and PKCE verifier.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	const op = "oidc.Provider.AuthCodeURL"
+
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange trades the authorization code for tokens and returns the verified ID token claims.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	const op = "oidc.Provider.Exchange"
+
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", verifier)
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrExchange, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrExchange, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w: status %d: %s", op, ErrExchange, resp.StatusCode, body)
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil || tokens.IDToken == "" {
+		return nil, fmt.Errorf("%s: %w: id_token missing", op, ErrExchange)
+	}
+
+	claims, err := p.verify(ctx, meta, tokens.IDToken, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return claims, nil
+}
+
+func (p *Provider) verify(ctx context.Context, meta *discovery, raw, nonce string) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.Parse(raw, func(header jwt.Header) (any, error) {
+		if header.Alg != jwt.AlgRS256 {
+			return nil, jwt.ErrUnsupportedAlg
+		}
+		return p.key(ctx, meta, header.Kid)
+	}, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if err := claims.Validate(time.Now(), clockSkew); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if claims.Issuer != meta.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if !claims.Audience.Contains(p.cfg.ClientID) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+
+	// second pass to keep every claim for the configurable claim mapping
+	parts := strings.Split(raw, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if err := json.Unmarshal(payload, &claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	return &claims, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	meta := p.meta
+	p.mu.Unlock()
+	if meta != nil {
+		return meta, nil
+	}
+
+	// concurrent first requests may both fetch, the metadata is the same either way
+	meta = new(discovery)
+	wellKnown := strings.TrimSuffix(p.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, wellKnown, meta); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiscovery, err)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete provider metadata", ErrDiscovery)
+	}
+
+	p.mu.Lock()
+	p.meta = meta
+	p.mu.Unlock()
+
+	return meta, nil
+}
+
+// key returns the signing key with the kid. The key set is refetched for unknown kids
+// so the provider can rotate keys, but at most once per refetchInterval.
+func (p *Provider) key(ctx context.Context, meta *discovery, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	if key, ok := p.keys[kid]; ok {
+		p.mu.Unlock()
+		return key, nil
+	}
+	if !p.keysFetchedAt.IsZero() && time.Since(p.keysFetchedAt) < p.refetchInterval {
+		p.mu.Unlock()
+		return nil, ErrUnknownKey
+	}
+	// claimed before fetching, so concurrent requests with unknown kids do not fetch too
+	p.keysFetchedAt = time.Now()
+	p.mu.Unlock()
+
+	keys, err := p.fetchKeys(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, meta *discovery) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// RandomString returns a URL safe random string used for state, nonce and PKCE verifiers.
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
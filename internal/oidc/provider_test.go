@@ -0,0 +1,195 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/oidc/oidctest"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jwt"
+	"net/url"
+	"testing"
+)
+
+const testClientID = "gateway"
+
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Server) {
+	t.Helper()
+
+	idp, err := oidctest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(idp.Close)
+
+	p := NewProvider(config.OIDCConfig{
+		ProviderName: "test",
+		IssuerURL:    idp.Issuer(),
+		ClientID:     testClientID,
+		RedirectURL:  "http://localhost/callback",
+		Scopes:       []string{"openid", "email"},
+	})
+	return p, idp
+}
+
+// login runs the code flow, verifier overrides the PKCE verifier sent on exchange if set.
+func login(t *testing.T, p *Provider, idp *oidctest.Server, claims map[string]any, verifier string) (*Claims, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	state, err := NewLoginState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := idp.Authorize(authURL, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if verifier == "" {
+		verifier = state.Verifier
+	}
+	return p.Exchange(ctx, code, verifier, state.Nonce)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	p, _ := newTestProvider(t)
+
+	authURL, err := p.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := u.Query()
+	// S256 of "verifier"
+	if q.Get("code_challenge") != "iMnq5o6zALKXGivsnlom_0F5_WYda32GHkxlV7mq7hQ" || q.Get("code_challenge_method") != "S256" {
+		t.Fatalf("PKCE challenge = %q %q", q.Get("code_challenge"), q.Get("code_challenge_method"))
+	}
+	if q.Get("state") != "state" || q.Get("nonce") != "nonce" || q.Get("client_id") != testClientID {
+		t.Fatalf("query = %v", q)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	p, idp := newTestProvider(t)
+
+	claims, err := login(t, p, idp, map[string]any{
+		"email":          "student@university.kz",
+		"email_verified": true,
+		"student_id":     220107,
+	}, "")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+
+	if claims.Subject != "subject-1" || claims.Email != "student@university.kz" || !claims.EmailIsVerified() {
+		t.Fatalf("Exchange() = %+v", claims)
+	}
+	if got := claims.String("student_id"); got != "220107" {
+		t.Fatalf(`String("student_id") = %q`, got)
+	}
+}
+
+func TestExchangeRejects(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		claims   map[string]any
+		verifier string
+		setup    func(idp *oidctest.Server)
+		wantErr  error
+	}{
+		{name: "wrong PKCE verifier", verifier: "not-the-verifier", wantErr: ErrExchange},
+		{name: "nonce mismatch", claims: map[string]any{"nonce": "other"}, wantErr: ErrInvalidToken},
+		{name: "wrong audience", claims: map[string]any{"aud": "other-client"}, wantErr: ErrInvalidToken},
+		{name: "wrong issuer", claims: map[string]any{"iss": "https://evil.example"}, wantErr: ErrInvalidToken},
+		{name: "expired", claims: map[string]any{"exp": 1}, wantErr: jwt.ErrExpired},
+		{name: "bad signature", setup: func(idp *oidctest.Server) { idp.SignWith(otherKey) }, wantErr: jwt.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, idp := newTestProvider(t)
+			if tt.setup != nil {
+				tt.setup(idp)
+			}
+
+			_, err := login(t, p, idp, tt.claims, tt.verifier)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Exchange() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailIsVerified(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   bool
+	}{
+		{"verified", map[string]any{"email_verified": true}, true},
+		{"not verified", map[string]any{"email_verified": false}, false},
+		{"claim missing", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, idp := newTestProvider(t)
+
+			claims, err := login(t, p, idp, tt.claims, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := claims.EmailIsVerified(); got != tt.want {
+				t.Fatalf("EmailIsVerified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyRefetchIsRateLimited(t *testing.T) {
+	p, idp := newTestProvider(t)
+
+	if _, err := login(t, p, idp, nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := idp.JWKSRequests(); got != 1 {
+		t.Fatalf("JWKS requests = %d, want 1", got)
+	}
+
+	if err := idp.RotateKey(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := login(t, p, idp, nil, ""); !errors.Is(err, ErrUnknownKey) {
+			t.Fatalf("Exchange() error = %v, want %v", err, ErrUnknownKey)
+		}
+	}
+	if got := idp.JWKSRequests(); got != 1 {
+		t.Fatalf("JWKS requests within the refetch interval = %d, want 1", got)
+	}
+
+	p.mu.Lock()
+	p.refetchInterval = 0
+	p.mu.Unlock()
+
+	if _, err := login(t, p, idp, nil, ""); err != nil {
+		t.Fatalf("after the refetch interval: Exchange() error = %v", err)
+	}
+	if got := idp.JWKSRequests(); got != 2 {
+		t.Fatalf("JWKS requests = %d, want 2", got)
+	}
+}
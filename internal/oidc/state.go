@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// StateTTL is how long a started login can be completed.
+const StateTTL = 10 * time.Minute
+
+var ErrInvalidState = errors.New("invalid login state")
+
+// LoginState is kept in a signed cookie between /auth/oidc/login and the callback.
+type LoginState struct {
+	State     string `json:"state"`
+	Nonce     string `json:"nonce"`
+	Verifier  string `json:"verifier"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// NewLoginState generates the state, nonce and PKCE verifier of a new login.
+func NewLoginState() (LoginState, error) {
+	state, err := RandomString()
+	if err != nil {
+		return LoginState{}, err
+	}
+	nonce, err := RandomString()
+	if err != nil {
+		return LoginState{}, err
+	}
+	verifier, err := RandomString()
+	if err != nil {
+		return LoginState{}, err
+	}
+
+	return LoginState{
+		State:     state,
+		Nonce:     nonce,
+		Verifier:  verifier,
+		ExpiresAt: time.Now().Add(StateTTL).Unix(),
+	}, nil
+}
+
+// SealState encodes and signs the login state for the state cookie.
+func (p *Provider) SealState(s LoginState) (string, error) {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + p.signState(encoded), nil
+}
+
+// OpenState verifies the state cookie and returns the login state if it has not expired.
+func (p *Provider) OpenState(sealed string) (LoginState, error) {
+	encoded, sig, ok := strings.Cut(sealed, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(p.signState(encoded))) {
+		return LoginState{}, ErrInvalidState
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return LoginState{}, ErrInvalidState
+	}
+
+	var s LoginState
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return LoginState{}, ErrInvalidState
+	}
+	if time.Now().Unix() >= s.ExpiresAt {
+		return LoginState{}, ErrInvalidState
+	}
+
+	return s, nil
+}
+
+func (p *Provider) signState(encoded string) string {
+	mac := hmac.New(sha256.New, p.stateSecret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func randomSecret() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,202 @@
+// Package realtime fans out gateway events to connected clients.
+// Events are published to topics, every subscriber listens to a set of topics,
+// and recent events are kept in a bounded buffer so reconnecting clients can
+// resume from the last event they received.
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Event types delivered to clients.
+const (
+	TypeJoinRequestCreated  = "join_request.created"
+	TypeJoinRequestDecided  = "join_request.decided"
+	TypeClubDecided         = "club.decided"
+	TypeNotificationCreated = "notification.created"
+	TypeAttendanceCheckedIn = "attendance.checked_in"
+)
+
+var ErrClosed = errors.New("hub is closed")
+
+type Event struct {
+	ID    uint64          `json:"id"`
+	Type  string          `json:"type"`
+	Topic string          `json:"-"`
+	Data  json.RawMessage `json:"data"`
+	Time  time.Time       `json:"time"`
+}
+
+// UserTopic is the topic of events addressed to a single user.
+func UserTopic(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// ClubTopic is the topic of events addressed to the members managing a club.
+func ClubTopic(clubID int64) string {
+	return "club:" + strconv.FormatInt(clubID, 10)
+}
+
+// AttendanceTopic is the topic of the attendance of a club, addressed to the members
+// managing its events.
+func AttendanceTopic(clubID int64) string {
+	return "attendance:" + strconv.FormatInt(clubID, 10)
+}
+
+// Subscription receives the events of its topics on C. C is closed when the hub
+// drops the subscriber for falling behind, or when the hub is closed.
+type Subscription struct {
+	C <-chan Event
+
+	ch     chan Event
+	topics map[string]struct{}
+	lagged bool
+}
+
+// Lagged reports whether the subscription was dropped because its queue was full,
+// the client should reconnect and resume with Last-Event-ID. Only valid after C is closed.
+func (s *Subscription) Lagged() bool {
+	return s.lagged
+}
+
+// Hub is safe for concurrent use.
+type Hub struct {
+	mu          sync.Mutex
+	lastID      uint64
+	buffer      []Event
+	next        int
+	full        bool
+	subscribers map[*Subscription]struct{}
+	clientBuf   int
+	closed      bool
+	log         *slog.Logger
+}
+
+// NewHub creates a hub that keeps the last bufferSize events for replay and gives
+// every subscriber a queue of clientBuffer events before it is dropped as too slow.
+func NewHub(bufferSize, clientBuffer int, log *slog.Logger) *Hub {
+	return &Hub{
+		buffer:      make([]Event, bufferSize),
+		subscribers: make(map[*Subscription]struct{}),
+		clientBuf:   clientBuffer,
+		log:         log,
+	}
+}
+
+// Publish sends the event to the subscribers of the topic without blocking.
+// Subscribers whose queue is full are dropped instead of slowing down the publisher.
+func (h *Hub) Publish(topic, eventType string, data any) {
+	const op = "realtime.Hub.Publish"
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		h.log.Error("failed to marshal event", slog.String("op", op), slog.String("type", eventType), slog.String("error", err.Error()))
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.lastID++
+	e := Event{ID: h.lastID, Type: eventType, Topic: topic, Data: raw, Time: time.Now().UTC()}
+
+	if len(h.buffer) > 0 {
+		h.buffer[h.next] = e
+		h.next = (h.next + 1) % len(h.buffer)
+		if h.next == 0 {
+			h.full = true
+		}
+	}
+
+	for sub := range h.subscribers {
+		if _, ok := sub.topics[topic]; !ok {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			sub.lagged = true
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe registers a subscriber for the topics. If lastEventID is not zero, the
+// buffered events of the topics published after it are returned for replay; complete
+// is false when older events were already evicted and some may have been missed.
+func (h *Hub) Subscribe(topics []string, lastEventID uint64) (sub *Subscription, replay []Event, complete bool, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, false, ErrClosed
+	}
+
+	ch := make(chan Event, h.clientBuf)
+	sub = &Subscription{C: ch, ch: ch, topics: make(map[string]struct{}, len(topics))}
+	for _, t := range topics {
+		sub.topics[t] = struct{}{}
+	}
+	h.subscribers[sub] = struct{}{}
+
+	if lastEventID == 0 {
+		return sub, nil, true, nil
+	}
+
+	buffered := h.buffered()
+	// IDs restart with the process, an ID from the future means the client missed a restart
+	complete = lastEventID == h.lastID ||
+		(lastEventID < h.lastID && len(buffered) > 0 && buffered[0].ID <= lastEventID+1)
+	for _, e := range buffered {
+		if _, ok := sub.topics[e.Topic]; ok && e.ID > lastEventID {
+			replay = append(replay, e)
+		}
+	}
+
+	return sub, replay, complete, nil
+}
+
+// Unsubscribe removes the subscriber, it is safe to call more than once.
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		h.remove(sub)
+	}
+}
+
+// Close disconnects every subscriber and stops accepting events.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		h.remove(sub)
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	delete(h.subscribers, sub)
+	close(sub.ch)
+}
+
+// buffered returns the buffered events from the oldest to the newest.
+func (h *Hub) buffered() []Event {
+	if !h.full {
+		return h.buffer[:h.next]
+	}
+	res := make([]Event, 0, len(h.buffer))
+	res = append(res, h.buffer[h.next:]...)
+	return append(res, h.buffer[:h.next]...)
+}
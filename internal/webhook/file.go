@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/jsonfile"
+	"sync"
+	"time"
+)
+
+// FileStorage keeps endpoints and deliveries in memory and writes them to a JSON file on
+// every change, so endpoints and pending deliveries survive a restart of the gateway.
+// It is meant for a single gateway instance.
+type FileStorage struct {
+	mu   sync.Mutex
+	path string
+	mem  *MemoryStorage
+}
+
+type fileContents struct {
+	Endpoints  []Endpoint `json:"endpoints"`
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+// NewFileStorage loads endpoints and deliveries from path, a missing file is treated as empty.
+func NewFileStorage(path string) (*FileStorage, error) {
+	const op = "webhook.NewFileStorage"
+
+	s := &FileStorage{path: path, mem: NewMemoryStorage()}
+
+	var contents fileContents
+	if err := jsonfile.Load(path, &contents); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, endpoint := range contents.Endpoints {
+		s.mem.endpoints[endpoint.ID] = endpoint
+	}
+	for _, delivery := range contents.Deliveries {
+		s.mem.deliveries[delivery.ID] = delivery
+	}
+
+	return s, nil
+}
+
+func (s *FileStorage) SaveEndpoint(ctx context.Context, endpoint Endpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.SaveEndpoint(ctx, endpoint); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *FileStorage) GetEndpoint(ctx context.Context, id string) (Endpoint, error) {
+	return s.mem.GetEndpoint(ctx, id)
+}
+
+func (s *FileStorage) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	return s.mem.ListEndpoints(ctx)
+}
+
+func (s *FileStorage) DeleteEndpoint(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.DeleteEndpoint(ctx, id); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *FileStorage) SaveDelivery(ctx context.Context, delivery Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.SaveDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *FileStorage) GetDelivery(ctx context.Context, id string) (Delivery, error) {
+	return s.mem.GetDelivery(ctx, id)
+}
+
+func (s *FileStorage) DeleteDelivery(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.DeleteDelivery(ctx, id); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *FileStorage) ListDeliveries(ctx context.Context, status string) ([]Delivery, error) {
+	return s.mem.ListDeliveries(ctx, status)
+}
+
+func (s *FileStorage) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error) {
+	return s.mem.DueDeliveries(ctx, now, limit)
+}
+
+// flush atomically replaces the file with the current endpoints and deliveries.
+func (s *FileStorage) flush() error {
+	const op = "webhook.FileStorage.flush"
+
+	s.mem.mu.RLock()
+	contents := fileContents{
+		Endpoints:  make([]Endpoint, 0, len(s.mem.endpoints)),
+		Deliveries: make([]Delivery, 0, len(s.mem.deliveries)),
+	}
+	for _, endpoint := range s.mem.endpoints {
+		contents.Endpoints = append(contents.Endpoints, endpoint)
+	}
+	for _, delivery := range s.mem.deliveries {
+		contents.Deliveries = append(contents.Deliveries, delivery)
+	}
+	s.mem.mu.RUnlock()
+
+	if err := jsonfile.Save(s.path, contents); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
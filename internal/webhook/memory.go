@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type MemoryStorage struct {
+	mu         sync.RWMutex
+	endpoints  map[string]Endpoint
+	deliveries map[string]Delivery
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		endpoints:  make(map[string]Endpoint),
+		deliveries: make(map[string]Delivery),
+	}
+}
+
+func (s *MemoryStorage) SaveEndpoint(_ context.Context, endpoint Endpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.endpoints[endpoint.ID] = endpoint
+	return nil
+}
+
+func (s *MemoryStorage) GetEndpoint(_ context.Context, id string) (Endpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	endpoint, ok := s.endpoints[id]
+	if !ok {
+		return Endpoint{}, ErrNotFound
+	}
+	return endpoint, nil
+}
+
+func (s *MemoryStorage) ListEndpoints(_ context.Context) ([]Endpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	endpoints := make([]Endpoint, 0, len(s.endpoints))
+	for _, endpoint := range s.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].CreatedAt.Before(endpoints[j].CreatedAt) })
+
+	return endpoints, nil
+}
+
+func (s *MemoryStorage) DeleteEndpoint(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.endpoints[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.endpoints, id)
+	return nil
+}
+
+func (s *MemoryStorage) SaveDelivery(_ context.Context, delivery Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (s *MemoryStorage) GetDelivery(_ context.Context, id string) (Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return Delivery{}, ErrNotFound
+	}
+	return delivery, nil
+}
+
+func (s *MemoryStorage) DeleteDelivery(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.deliveries, id)
+	return nil
+}
+
+func (s *MemoryStorage) ListDeliveries(_ context.Context, status string) ([]Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deliveries []Delivery
+	for _, delivery := range s.deliveries {
+		if delivery.Status == status {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) })
+
+	return deliveries, nil
+}
+
+func (s *MemoryStorage) DueDeliveries(_ context.Context, now time.Time, limit int) ([]Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deliveries []Delivery
+	for _, delivery := range s.deliveries {
+		if delivery.Status == StatusPending && !delivery.NextAt.After(now) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].NextAt.Before(deliveries[j].NextAt) })
+
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,332 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ARUMANDESU/university-clubs-backend/internal/config"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/logger"
+	"github.com/ARUMANDESU/university-clubs-backend/pkg/randid"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// maxErrorBody limits how much of a failed response is kept in Delivery.LastError.
+const maxErrorBody = 512
+
+// Service registers endpoints, records deliveries for dispatched events and sends
+// them from a background worker, retrying failures with exponential backoff.
+type Service struct {
+	storage        Storage
+	client         *http.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	workers        int
+	pollInterval   time.Duration
+	wake           chan struct{}
+	log            *slog.Logger
+}
+
+func NewService(storage Storage, cfg config.WebhookConfig, log *slog.Logger) *Service {
+	return &Service{
+		storage:        storage,
+		client:         &http.Client{Timeout: cfg.Timeout},
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: cfg.InitialBackoff,
+		maxBackoff:     cfg.MaxBackoff,
+		workers:        cfg.Workers,
+		pollInterval:   cfg.PollInterval,
+		wake:           make(chan struct{}, 1),
+		log:            log,
+	}
+}
+
+type RegisterParams struct {
+	URL         string
+	Description string
+	Events      []string
+	CreatedBy   int64
+}
+
+// Register creates an endpoint with a new signing secret.
+func (s *Service) Register(ctx context.Context, params RegisterParams) (Endpoint, error) {
+	const op = "webhook.Service.Register"
+
+	u, err := url.Parse(params.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Endpoint{}, fmt.Errorf("%s: %w", op, ErrInvalidURL)
+	}
+	for _, ev := range params.Events {
+		if !validEvent(ev) {
+			return Endpoint{}, fmt.Errorf("%s: %w: %s", op, ErrInvalidEvent, ev)
+		}
+	}
+
+	id, err := randid.New("whe_", 8)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("%s: %w", op, err)
+	}
+	secret, err := randid.New("whsec_", 32)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	endpoint := Endpoint{
+		ID:          id,
+		URL:         u.String(),
+		Description: params.Description,
+		Events:      params.Events,
+		Secret:      secret,
+		CreatedBy:   params.CreatedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.storage.SaveEndpoint(ctx, endpoint); err != nil {
+		return Endpoint{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return endpoint, nil
+}
+
+func (s *Service) Endpoints(ctx context.Context) ([]Endpoint, error) {
+	const op = "webhook.Service.Endpoints"
+
+	endpoints, err := s.storage.ListEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return endpoints, nil
+}
+
+// Unregister deletes the endpoint, its pending deliveries are dropped on their next attempt.
+func (s *Service) Unregister(ctx context.Context, id string) error {
+	const op = "webhook.Service.Unregister"
+
+	if err := s.storage.DeleteEndpoint(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// DeadLetters returns the deliveries that ran out of attempts, newest first.
+func (s *Service) DeadLetters(ctx context.Context) ([]Delivery, error) {
+	const op = "webhook.Service.DeadLetters"
+
+	deliveries, err := s.storage.ListDeliveries(ctx, StatusDead)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+// Replay schedules the delivery to be sent again right away with a fresh attempt budget.
+func (s *Service) Replay(ctx context.Context, id string) (Delivery, error) {
+	const op = "webhook.Service.Replay"
+
+	delivery, err := s.storage.GetDelivery(ctx, id)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now().UTC()
+	delivery.Status = StatusPending
+	delivery.Attempts = 0
+	delivery.LastError = ""
+	delivery.NextAt = now
+	delivery.UpdatedAt = now
+	if err := s.storage.SaveDelivery(ctx, delivery); err != nil {
+		return Delivery{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.notify()
+	return delivery, nil
+}
+
+// Dispatch records a delivery of the event for every subscribed endpoint.
+// The deliveries are sent by Run, Dispatch does not wait for them.
+func (s *Service) Dispatch(ctx context.Context, eventType string, data any) error {
+	const op = "webhook.Service.Dispatch"
+
+	endpoints, err := s.storage.ListEndpoints(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	eventID, err := randid.New("evt_", 12)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	now := time.Now().UTC()
+
+	payload, err := json.Marshal(Payload{ID: eventID, Type: eventType, CreatedAt: now, Data: data})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribed(eventType) {
+			continue
+		}
+
+		id, err := randid.New("whd_", 12)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		delivery := Delivery{
+			ID:         id,
+			EndpointID: endpoint.ID,
+			EventID:    eventID,
+			EventType:  eventType,
+			Payload:    payload,
+			Status:     StatusPending,
+			NextAt:     now,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if err := s.storage.SaveDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	s.notify()
+	return nil
+}
+
+// Run sends due deliveries until the context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	const op = "webhook.Service.Run"
+	log := s.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.deliverDue(ctx); err != nil {
+			log.Error("failed to deliver webhooks", logger.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) deliverDue(ctx context.Context) error {
+	deliveries, err := s.storage.DueDeliveries(ctx, time.Now(), s.workers*4)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.workers)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery Delivery) {
+			defer func() { <-sem; wg.Done() }()
+			s.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (s *Service) deliver(ctx context.Context, delivery Delivery) {
+	const op = "webhook.Service.deliver"
+	log := s.log.With(slog.String("op", op), slog.String("delivery_id", delivery.ID), slog.String("endpoint_id", delivery.EndpointID))
+
+	endpoint, err := s.storage.GetEndpoint(ctx, delivery.EndpointID)
+	if err != nil {
+		// the endpoint was deleted, there is nowhere to retry
+		delivery.Status = StatusDead
+		delivery.LastError = "endpoint not found"
+		s.save(ctx, log, delivery)
+		return
+	}
+
+	delivery.Attempts++
+	statusCode, err := s.send(ctx, endpoint, delivery)
+	delivery.LastStatus = statusCode
+	delivery.UpdatedAt = time.Now().UTC()
+
+	switch {
+	case err == nil:
+		// delivered deliveries are not kept, so storage and polling only deal with
+		// pending and dead ones
+		if err := s.storage.DeleteDelivery(ctx, delivery.ID); err != nil {
+			log.Error("failed to delete delivered delivery", logger.Err(err))
+		}
+		return
+	case delivery.Attempts >= s.maxAttempts:
+		log.Warn("webhook delivery moved to dead letters", slog.Int("attempts", delivery.Attempts), logger.Err(err))
+		delivery.Status = StatusDead
+		delivery.LastError = err.Error()
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAt = delivery.UpdatedAt.Add(s.backoff(delivery.Attempts))
+	}
+
+	s.save(ctx, log, delivery)
+}
+
+func (s *Service) send(ctx context.Context, endpoint Endpoint, delivery Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "uniclubs-webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, time.Now(), delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt: initialBackoff doubled per
+// failed attempt, capped at maxBackoff, plus up to 10% jitter.
+func (s *Service) backoff(attempts int) time.Duration {
+	d := s.initialBackoff
+	for i := 1; i < attempts && d < s.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > s.maxBackoff {
+		d = s.maxBackoff
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/10+1))
+}
+
+func (s *Service) save(ctx context.Context, log *slog.Logger, delivery Delivery) {
+	if err := s.storage.SaveDelivery(ctx, delivery); err != nil {
+		log.Error("failed to save delivery", logger.Err(err))
+	}
+}
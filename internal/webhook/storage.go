@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// Storage persists endpoints and deliveries.
+type Storage interface {
+	SaveEndpoint(ctx context.Context, endpoint Endpoint) error
+	// GetEndpoint returns ErrNotFound if there is no endpoint with the id.
+	GetEndpoint(ctx context.Context, id string) (Endpoint, error)
+	ListEndpoints(ctx context.Context) ([]Endpoint, error)
+	// DeleteEndpoint returns ErrNotFound if there is no endpoint with the id.
+	DeleteEndpoint(ctx context.Context, id string) error
+
+	SaveDelivery(ctx context.Context, delivery Delivery) error
+	// GetDelivery returns ErrNotFound if there is no delivery with the id.
+	GetDelivery(ctx context.Context, id string) (Delivery, error)
+	// DeleteDelivery removes the delivery, missing deliveries are ignored.
+	DeleteDelivery(ctx context.Context, id string) error
+	// ListDeliveries returns the deliveries with the status, newest first.
+	ListDeliveries(ctx context.Context, status string) ([]Delivery, error)
+	// DueDeliveries returns at most limit pending deliveries whose next attempt is due.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error)
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Event types sent to webhook endpoints.
+const (
+	EventClubCreated        = "club.created"
+	EventClubApproved       = "club.approved"
+	EventClubRejected       = "club.rejected"
+	EventMembershipApproved = "membership.approved"
+	EventMembershipRejected = "membership.rejected"
+	EventMembershipAdded    = "membership.added"
+
+	// EventAll subscribes an endpoint to every event type.
+	EventAll = "*"
+)
+
+// Events lists every event type an endpoint can subscribe to.
+var Events = []string{
+	EventClubCreated,
+	EventClubApproved,
+	EventClubRejected,
+	EventMembershipApproved,
+	EventMembershipRejected,
+	EventMembershipAdded,
+}
+
+// Headers set on every delivery.
+const (
+	HeaderSignature = "X-Uniclubs-Signature"
+	HeaderEvent     = "X-Uniclubs-Event"
+	HeaderDelivery  = "X-Uniclubs-Delivery"
+)
+
+// Delivery statuses. Delivered deliveries are deleted, only pending and dead ones are kept.
+const (
+	StatusPending = "pending"
+	// StatusDead marks deliveries that ran out of attempts, they stay in the dead-letter list until replayed.
+	StatusDead = "dead"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEvent = errors.New("invalid event type")
+	ErrInvalidURL   = errors.New("invalid endpoint url")
+)
+
+type Endpoint struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Events      []string  `json:"events"`
+	Secret      string    `json:"secret"`
+	CreatedBy   int64     `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Subscribed reports whether the endpoint receives the event type.
+func (e Endpoint) Subscribed(eventType string) bool {
+	for _, ev := range e.Events {
+		if ev == EventAll || ev == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type Delivery struct {
+	ID         string    `json:"id"`
+	EndpointID string    `json:"endpoint_id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Payload    []byte    `json:"payload"`
+	Status     string    `json:"status"`
+	Attempts   int       `json:"attempts"`
+	LastStatus int       `json:"last_status,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+	NextAt     time.Time `json:"next_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Payload is the JSON body posted to endpoints.
+type Payload struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// Sign returns the signature header value "t=<unix timestamp>,v1=<hex hmac>".
+// The HMAC-SHA256 covers "<timestamp>.<body>", receivers should recompute it with
+// their endpoint secret and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func validEvent(eventType string) bool {
+	if eventType == EventAll {
+		return true
+	}
+	for _, ev := range Events {
+		if ev == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package grpcjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Name is the content-subtype used on the wire ("application/grpc+json").
+const Name = "json"
+
+// Codec marshals gRPC messages as JSON. Protobuf messages are encoded with protojson,
+// everything else with encoding/json. It is used for service methods that are not
+// yet published in uniclubs-protos, so plain Go structs can describe their contract.
+type Codec struct{}
+
+func init() {
+	encoding.RegisterCodec(Codec{})
+}
+
+func (Codec) Marshal(v any) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.Marshal(m)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("grpcjson.Marshal: %w", err)
+	}
+	return b, nil
+}
+
+func (Codec) Unmarshal(data []byte, v any) error {
+	if m, ok := v.(proto.Message); ok {
+		return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, m)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("grpcjson.Unmarshal: %w", err)
+	}
+	return nil
+}
+
+func (Codec) Name() string {
+	return Name
+}
+
+// CallOption forces the JSON codec for a single call.
+func CallOption() grpc.CallOption {
+	return grpc.CallContentSubtype(Name)
+}
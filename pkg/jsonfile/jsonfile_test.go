@@ -0,0 +1,58 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type record struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags"`
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	v := []record{{ID: "kept"}}
+	if err := Load(filepath.Join(t.TempDir(), "missing.json"), &v); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(v) != 1 || v[0].ID != "kept" {
+		t.Errorf("Load() changed the value to %v", v)
+	}
+}
+
+func TestSaveReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "records.json")
+
+	if err := Save(path, []record{{ID: "a"}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := Save(path, []record{{ID: "b", Tags: []string{"x"}}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	var got []record
+	if err := Load(path, &got); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "b" || len(got[0].Tags) != 1 {
+		t.Errorf("Load() = %v, want the second save", got)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("permissions = %o, want 600", perm)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, the temporary files must be removed", len(entries))
+	}
+}
@@ -0,0 +1,178 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+var (
+	ErrMalformed        = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrExpired          = errors.New("token is expired")
+	ErrNotYetValid      = errors.New("token is not valid yet")
+)
+
+type Header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// Audience is the "aud" claim, which may be encoded as a string or an array of strings.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a Audience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+type RegisteredClaims struct {
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	ID        string   `json:"jti,omitempty"`
+}
+
+// Validate checks the time based claims allowing the given clock skew.
+func (c RegisteredClaims) Validate(now time.Time, leeway time.Duration) error {
+	if c.ExpiresAt != 0 && now.Add(-leeway).Unix() >= c.ExpiresAt {
+		return ErrExpired
+	}
+	if c.NotBefore != 0 && now.Add(leeway).Unix() < c.NotBefore {
+		return ErrNotYetValid
+	}
+	return nil
+}
+
+// KeyFunc returns the verification key for the token header:
+// a []byte secret for HS256 or an *rsa.PublicKey for RS256.
+type KeyFunc func(header Header) (any, error)
+
+// SignHS256 encodes the claims and signs them with the HMAC key identified by kid.
+func SignHS256(kid string, key []byte, claims any) (string, error) {
+	const op = "jwt.SignHS256"
+
+	header, err := json.Marshal(Header{Alg: AlgHS256, Typ: "JWT", Kid: kid})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	signingInput := encode(header) + "." + encode(payload)
+	return signingInput + "." + encode(hmacSHA256(key, signingInput)), nil
+}
+
+// Parse verifies the token signature with the key returned by keyFunc and decodes
+// the payload into claims. Time based claims are not checked, see RegisteredClaims.Validate.
+func Parse(token string, keyFunc KeyFunc, claims any) (Header, error) {
+	const op = "jwt.Parse"
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Header{}, fmt.Errorf("%s: %w", op, ErrMalformed)
+	}
+
+	var header Header
+	if err := decodeJSON(parts[0], &header); err != nil {
+		return Header{}, fmt.Errorf("%s: %w", op, ErrMalformed)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return header, fmt.Errorf("%s: %w", op, ErrMalformed)
+	}
+
+	key, err := keyFunc(header)
+	if err != nil {
+		return header, fmt.Errorf("%s: %w", op, err)
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	switch header.Alg {
+	case AlgHS256:
+		secret, ok := key.([]byte)
+		if !ok || !hmac.Equal(signature, hmacSHA256(secret, signingInput)) {
+			return header, fmt.Errorf("%s: %w", op, ErrInvalidSignature)
+		}
+	case AlgRS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return header, fmt.Errorf("%s: %w", op, ErrInvalidSignature)
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature); err != nil {
+			return header, fmt.Errorf("%s: %w", op, ErrInvalidSignature)
+		}
+	default:
+		return header, fmt.Errorf("%s: %w: %s", op, ErrUnsupportedAlg, header.Alg)
+	}
+
+	if err := decodeJSON(parts[1], claims); err != nil {
+		return header, fmt.Errorf("%s: %w", op, ErrMalformed)
+	}
+
+	return header, nil
+}
+
+// LooksLikeJWT reports whether the token has the three dot separated JWS segments,
+// it is used to tell JWTs apart from opaque session tokens.
+func LooksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+func hmacSHA256(key []byte, input string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeJSON(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
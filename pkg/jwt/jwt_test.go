@@ -0,0 +1,165 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("test-secret")
+
+func hsKey(header Header) (any, error) {
+	if header.Alg != AlgHS256 {
+		return nil, ErrUnsupportedAlg
+	}
+	return testSecret, nil
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, header Header, claims any) string {
+	t.Helper()
+
+	h, _ := json.Marshal(header)
+	p, _ := json.Marshal(claims)
+	input := encode(h) + "." + encode(p)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + encode(sig)
+}
+
+func unsigned(header Header, claims any) string {
+	h, _ := json.Marshal(header)
+	p, _ := json.Marshal(claims)
+	return encode(h) + "." + encode(p) + "."
+}
+
+func TestSignAndParseHS256(t *testing.T) {
+	token, err := SignHS256("k1", testSecret, RegisteredClaims{Subject: "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var claims RegisteredClaims
+	header, err := Parse(token, hsKey, &claims)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if header.Kid != "k1" || claims.Subject != "42" {
+		t.Fatalf("Parse() = %+v, %+v", header, claims)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	rsaKeyFunc := func(Header) (any, error) { return &rsaKey.PublicKey, nil }
+
+	valid, err := SignHS256("k1", testSecret, RegisteredClaims{Subject: "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(valid, ".")
+
+	// HS256 signed with the public key, verified by a key func that ignores the alg
+	confused, err := SignHS256("k1", pubPEM, RegisteredClaims{Subject: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		keyFunc KeyFunc
+		wantErr error
+	}{
+		{"malformed", "abc.def", hsKey, ErrMalformed},
+		{"alg none", unsigned(Header{Alg: "none"}, RegisteredClaims{Subject: "1"}), hsKey, ErrUnsupportedAlg},
+		{"alg none accepted by key func", unsigned(Header{Alg: "none"}, RegisteredClaims{Subject: "1"}), func(Header) (any, error) { return testSecret, nil }, ErrUnsupportedAlg},
+		{"tampered payload", parts[0] + "." + encode([]byte(`{"sub":"1"}`)) + "." + parts[2], hsKey, ErrInvalidSignature},
+		{"wrong secret", valid, func(Header) (any, error) { return []byte("other"), nil }, ErrInvalidSignature},
+		{"hs256 with rsa public key", confused, rsaKeyFunc, ErrInvalidSignature},
+		{"rs256 with hmac secret", signRS256(t, rsaKey, Header{Alg: AlgRS256}, RegisteredClaims{Subject: "1"}), func(Header) (any, error) { return testSecret, nil }, ErrInvalidSignature},
+		{"rs256 rejected by key func", signRS256(t, rsaKey, Header{Alg: AlgRS256}, RegisteredClaims{Subject: "1"}), hsKey, ErrUnsupportedAlg},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var claims RegisteredClaims
+			_, err := Parse(tt.token, tt.keyFunc, &claims)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := signRS256(t, key, Header{Alg: AlgRS256, Kid: "idp"}, RegisteredClaims{Subject: "42"})
+
+	var claims RegisteredClaims
+	if _, err := Parse(token, func(Header) (any, error) { return &key.PublicKey, nil }, &claims); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if claims.Subject != "42" {
+		t.Fatalf("Subject = %q", claims.Subject)
+	}
+}
+
+func TestRegisteredClaimsValidate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name    string
+		claims  RegisteredClaims
+		leeway  time.Duration
+		wantErr error
+	}{
+		{"no time claims", RegisteredClaims{}, 0, nil},
+		{"valid", RegisteredClaims{ExpiresAt: now.Unix() + 60, NotBefore: now.Unix() - 60}, 0, nil},
+		{"expired", RegisteredClaims{ExpiresAt: now.Unix() - 1}, 0, ErrExpired},
+		{"expires now", RegisteredClaims{ExpiresAt: now.Unix()}, 0, ErrExpired},
+		{"expired within leeway", RegisteredClaims{ExpiresAt: now.Unix() - 10}, time.Minute, nil},
+		{"not yet valid", RegisteredClaims{NotBefore: now.Unix() + 1}, 0, ErrNotYetValid},
+		{"not yet valid within leeway", RegisteredClaims{NotBefore: now.Unix() + 10}, time.Minute, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.claims.Validate(now, tt.leeway); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAudienceUnmarshal(t *testing.T) {
+	for _, raw := range []string{`{"aud":"client"}`, `{"aud":["other","client"]}`} {
+		var claims RegisteredClaims
+		if err := json.Unmarshal([]byte(raw), &claims); err != nil {
+			t.Fatal(err)
+		}
+		if !claims.Audience.Contains("client") {
+			t.Fatalf("%s: Audience = %v", raw, claims.Audience)
+		}
+	}
+}
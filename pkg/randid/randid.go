@@ -0,0 +1,16 @@
+// Package randid generates random identifiers.
+package randid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// New returns the prefix followed by n random bytes encoded as hex.
+func New(prefix string, n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of Allow calls between removals of idle buckets.
+const sweepEvery = 1024
+
+// Limiter is an in-memory keyed token bucket limiter. Every key gets a bucket holding
+// up to limit tokens that refills at limit tokens per window.
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	calls   int
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	window time.Duration
+}
+
+func New() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of the key. If the bucket is empty it returns
+// false and how long to wait until the next token is available.
+// A limit less than or equal to zero disables limiting.
+func (l *Limiter) Allow(key string, limit int, window time.Duration) (bool, time.Duration) {
+	if limit <= 0 || window <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit), last: now, window: window}
+		l.buckets[key] = b
+	}
+
+	rate := float64(limit) / window.Seconds()
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > float64(limit) {
+		b.tokens = float64(limit)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+		return false, wait
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// sweep drops buckets that have been idle long enough to be full again.
+func (l *Limiter) sweep(now time.Time) {
+	l.calls++
+	if l.calls < sweepEvery {
+		return
+	}
+	l.calls = 0
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > b.window {
+			delete(l.buckets, key)
+		}
+	}
+}
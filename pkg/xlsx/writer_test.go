@@ -0,0 +1,142 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"testing"
+)
+
+type sheetXML struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref  string `xml:"r,attr"`
+			Type string `xml:"t,attr"`
+			Text string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type workbookXML struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+func readPart(t *testing.T, r *zip.Reader, name string) []byte {
+	t.Helper()
+
+	f, err := r.Open(name)
+	if err != nil {
+		t.Fatalf("open %s: %v", name, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestWriter(t *testing.T) {
+	wide := make([]string, 30)
+	for i := range wide {
+		wide[i] = "col " + strconv.Itoa(i)
+	}
+	rows := [][]string{
+		{"name", "note"},
+		{`Tom & "Jerry"`, "<b>bold</b> it's"},
+		{"  padded  ", "line\nbreak"},
+		wide,
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Members & <Guests>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels"} {
+		readPart(t, r, name)
+	}
+
+	var wb workbookXML
+	if err := xml.Unmarshal(readPart(t, r, "xl/workbook.xml"), &wb); err != nil {
+		t.Fatal(err)
+	}
+	if len(wb.Sheets) != 1 || wb.Sheets[0].Name != "Members & <Guests>" {
+		t.Fatalf("sheets = %+v", wb.Sheets)
+	}
+
+	var sheet sheetXML
+	if err := xml.Unmarshal(readPart(t, r, "xl/worksheets/sheet1.xml"), &sheet); err != nil {
+		t.Fatal(err)
+	}
+	if len(sheet.Rows) != len(rows) {
+		t.Fatalf("got %d rows, want %d", len(sheet.Rows), len(rows))
+	}
+	for i, row := range sheet.Rows {
+		rowRef := strconv.Itoa(i + 1)
+		if row.Ref != rowRef {
+			t.Errorf("row %d: r = %q, want %q", i, row.Ref, rowRef)
+		}
+		if len(row.Cells) != len(rows[i]) {
+			t.Fatalf("row %d: got %d cells, want %d", i, len(row.Cells), len(rows[i]))
+		}
+		for j, cell := range row.Cells {
+			if want := columnName(j) + rowRef; cell.Ref != want {
+				t.Errorf("row %d cell %d: r = %q, want %q", i, j, cell.Ref, want)
+			}
+			if cell.Type != "inlineStr" {
+				t.Errorf("row %d cell %d: t = %q, want inlineStr", i, j, cell.Type)
+			}
+			if cell.Text != rows[i][j] {
+				t.Errorf("row %d cell %d: text = %q, want %q", i, j, cell.Text, rows[i][j])
+			}
+		}
+	}
+
+	last := sheet.Rows[3].Cells
+	if last[25].Ref != "Z4" || last[26].Ref != "AA4" || last[29].Ref != "AD4" {
+		t.Errorf("cells past Z: %s, %s, %s", last[25].Ref, last[26].Ref, last[29].Ref)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{1, "B"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+		{16383, "XFD"},
+	}
+	for _, tt := range tests {
+		if got := columnName(tt.index); got != tt.want {
+			t.Errorf("columnName(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}